@@ -0,0 +1,143 @@
+package lamway
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/cfn"
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/danteay/lamway/request"
+	"github.com/danteay/lamway/response"
+)
+
+// EventAdapter translates the events of a source into http requests, and the handler responses back into the
+// payload returned to Lambda. Custom envelopes can be supported creating the gateway for json.RawMessage events and
+// registering an adapter for them with WithAdapter.
+type EventAdapter[T any] interface {
+	// DecodeRequest builds the http request served by the handler from the event.
+	DecodeRequest(ctx context.Context, evt T) (*http.Request, error)
+	// EncodeResponse builds the Lambda response payload for the event. The ctx is the one of the request returned by
+	// DecodeRequest, so values added to it while decoding are available. It's also called with the invocation ctx
+	// and the default error response when DecodeRequest fails.
+	EncodeResponse(ctx context.Context, evt T, res response.APIGatewayResponse) (any, error)
+}
+
+// StreamingAdapter is implemented by the adapters whose source supports response streaming. When the gateway is
+// configured WithResponseStreaming and SupportsStreaming returns true, the handler output is streamed instead of
+// passed to EncodeResponse.
+type StreamingAdapter[T any] interface {
+	EventAdapter[T]
+	SupportsStreaming(evt T) bool
+}
+
+// StreamEncoder is implemented by the adapters whose source receives the handler output through a side channel, like
+// S3 Object Lambda, instead of in the Lambda response. The handler output is always streamed to EncodeStream, which
+// must consume the body before returning.
+type StreamEncoder[T any] interface {
+	EventAdapter[T]
+	EncodeStream(ctx context.Context, evt T, res *events.LambdaFunctionURLStreamingResponse) (any, error)
+}
+
+// defaultAdapter returns the built-in EventAdapter or BatchAdapter for the T event type.
+func defaultAdapter[T Event](o options) any {
+	var adapter any
+
+	switch any(*new(T)).(type) {
+	case events.APIGatewayProxyRequest:
+		adapter = APIGatewayV1Adapter{}
+	case events.APIGatewayV2HTTPRequest:
+		adapter = APIGatewayV2Adapter{}
+	case events.APIGatewayWebsocketProxyRequest:
+		adapter = WebsocketAdapter{ConnectionManager: o.connManager}
+	case events.LambdaFunctionURLRequest:
+		adapter = FunctionURLAdapter{}
+	case events.ALBTargetGroupRequest:
+		adapter = ALBAdapter{}
+	case events.APIGatewayCustomAuthorizerRequestTypeRequest:
+		adapter = AuthorizerAdapter{}
+	case events.APIGatewayCustomAuthorizerRequest:
+		adapter = TokenAuthorizerAdapter{}
+	case events.APIGatewayV2CustomAuthorizerV2Request:
+		adapter = AuthorizerV2Adapter{}
+	case request.VPCLatticeEventV1:
+		adapter = VPCLatticeV1Adapter{}
+	case request.VPCLatticeEventV2:
+		adapter = VPCLatticeV2Adapter{}
+	case request.CloudFrontEvent:
+		adapter = CloudFrontAdapter{}
+	case request.BedrockAgentEvent:
+		adapter = BedrockAgentAdapter{}
+	case request.AppSyncResolverEvent:
+		adapter = AppSyncAdapter{}
+	case []request.AppSyncResolverEvent:
+		adapter = AppSyncBatchAdapter{}
+	case request.DirectInvokeEvent:
+		adapter = DirectInvokeAdapter{}
+	case events.SQSEvent:
+		adapter = SQSAdapter{}
+	case events.KinesisEvent:
+		adapter = KinesisAdapter{}
+	case events.DynamoDBEvent:
+		adapter = DynamoDBAdapter{}
+	case events.KafkaEvent:
+		adapter = KafkaAdapter{}
+	case events.KinesisFirehoseEvent:
+		adapter = FirehoseAdapter{}
+	case cfn.Event:
+		adapter = CloudFormationAdapter{}
+	case request.StepFunctionsTask:
+		adapter = StepFunctionsAdapter{}
+	case events.S3ObjectLambdaEvent:
+		adapter = S3ObjectLambdaAdapter{}
+	case events.EventBridgeEvent:
+		adapter = EventBridgeAdapter{}
+	case events.SNSEvent:
+		adapter = SNSAdapter{}
+	case events.S3Event:
+		adapter = S3Adapter{}
+	case json.RawMessage:
+		adapter = AutoAdapter{ConnectionManager: o.connManager}
+	}
+
+	switch adapter.(type) {
+	case EventAdapter[T], BatchAdapter[T]:
+		return adapter
+	}
+
+	// the Event constraint rejects unsupported types at build time, this is only reached if a type in the
+	// constraint is missing a case above
+	return invalidAdapter[T]{}
+}
+
+// invalidAdapter fails every invocation with ErrInvalidAPIGatewayRequest.
+type invalidAdapter[T any] struct{}
+
+// DecodeRequest implementation.
+func (invalidAdapter[T]) DecodeRequest(context.Context, T) (*http.Request, error) {
+	return nil, ErrInvalidAPIGatewayRequest
+}
+
+// EncodeResponse implementation.
+func (invalidAdapter[T]) EncodeResponse(_ context.Context, _ T, res response.APIGatewayResponse) (any, error) {
+	return res.ToV1Map(), nil
+}
+
+// pathOrDefault returns path, or fallback when the adapter wasn't configured with one.
+func pathOrDefault(path, fallback string) string {
+	if path == "" {
+		return fallback
+	}
+
+	return path
+}
+
+// failed reports whether the handler answered with a non 2xx status code. Like net/http, a handler that doesn't
+// write anything answered with a 200.
+//
+// Adapters failing the invocation with a named error return a messages.InvokeResponse_Error, which the runtime
+// reports as it is instead of naming the error after its Go type.
+func failed(res response.APIGatewayResponse) bool {
+	return res.StatusCode != 0 && (res.StatusCode < 200 || res.StatusCode > 299)
+}
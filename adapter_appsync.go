@@ -0,0 +1,173 @@
+package lamway
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/aws/aws-lambda-go/lambda/messages"
+
+	"github.com/danteay/lamway/request"
+	"github.com/danteay/lamway/response"
+)
+
+const defaultGraphQLPath = "/graphql"
+
+// AppSyncAdapter is the built-in adapter for AppSync direct Lambda resolvers. The field being resolved is served as a
+// GraphQL POST request to Path, "/graphql" by default, and the value of the field in the handler response is
+// returned as the resolver result.
+//
+// The query is built with Query, request.AppSyncQuery by default, which needs a custom function for fields with enum
+// arguments or of types other than Query, Mutation and Subscription.
+type AppSyncAdapter struct {
+	Path  string
+	Query request.AppSyncQueryFunc
+}
+
+// DecodeRequest implementation.
+func (a AppSyncAdapter) DecodeRequest(ctx context.Context, evt request.AppSyncResolverEvent) (*http.Request, error) {
+	return request.NewAppSync(ctx, evt, pathOrDefault(a.Path, defaultGraphQLPath), a.Query)
+}
+
+// EncodeResponse implementation. When the handler answers with GraphQL errors or a non 2xx status code a
+// messages.InvokeResponse_Error is returned, which AppSync adds to the `errors` of the GraphQL response keeping its
+// errorType.
+func (AppSyncAdapter) EncodeResponse(_ context.Context, evt request.AppSyncResolverEvent, res response.APIGatewayResponse) (any, error) {
+	data, errRes := appSyncResult(evt.Info.FieldName, res)
+	if errRes != nil {
+		return nil, *errRes
+	}
+
+	return data, nil
+}
+
+// AppSyncBatchAdapter is the built-in adapter for AppSync direct Lambda resolvers with batching enabled. Every event
+// of the batch is served as an AppSyncAdapter request, up to Concurrency at the same time, and the results are
+// returned in the same order.
+type AppSyncBatchAdapter struct {
+	Path        string
+	Query       request.AppSyncQueryFunc
+	Concurrency int
+}
+
+// DecodeBatch implementation.
+func (a AppSyncBatchAdapter) DecodeBatch(ctx context.Context, evts []request.AppSyncResolverEvent) (Batch, error) {
+	batch := Batch{
+		Requests:    make([]BatchRequest, 0, len(evts)),
+		Concurrency: a.Concurrency,
+	}
+
+	for i, evt := range evts {
+		req, err := request.NewAppSync(ctx, evt, pathOrDefault(a.Path, defaultGraphQLPath), a.Query)
+		if err != nil {
+			return Batch{}, err
+		}
+
+		batch.Requests = append(batch.Requests, BatchRequest{ID: strconv.Itoa(i), Request: req})
+	}
+
+	return batch, nil
+}
+
+// EncodeBatch implementation. Each result is returned as `{"data": ..., "errorMessage": ..., "errorType": ...}`, so
+// a failed field doesn't fail the whole batch.
+func (AppSyncBatchAdapter) EncodeBatch(_ context.Context, evts []request.AppSyncResolverEvent, results []BatchResult) (any, error) {
+	out := make([]map[string]any, len(results))
+
+	for i, result := range results {
+		data, errRes := appSyncResult(evts[i].Info.FieldName, result.Response)
+		if errRes != nil {
+			out[i] = map[string]any{"data": nil, "errorMessage": errRes.Message, "errorType": errRes.Type}
+			continue
+		}
+
+		out[i] = map[string]any{"data": data}
+	}
+
+	return out, nil
+}
+
+// graphQLResponse is the body returned by GraphQL servers.
+type graphQLResponse struct {
+	Data   map[string]json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string `json:"message"`
+		ErrorType  string `json:"errorType"`
+		Extensions struct {
+			Code string `json:"code"`
+		} `json:"extensions"`
+	} `json:"errors"`
+}
+
+// appSyncResult extracts the value of field from the handler response. Bodies that aren't a GraphQL response are
+// returned as they are, decoded when they contain JSON.
+func appSyncResult(field string, res response.APIGatewayResponse) (any, *messages.InvokeResponse_Error) {
+	body := []byte(res.Body)
+
+	if res.IsBase64Encoded {
+		b, err := base64.StdEncoding.DecodeString(res.Body)
+		if err != nil {
+			return nil, &messages.InvokeResponse_Error{Message: err.Error(), Type: "BadResponse"}
+		}
+
+		body = b
+	}
+
+	var envelope map[string]json.RawMessage
+
+	isJSON := json.Unmarshal(body, &envelope) == nil
+	_, hasData := envelope["data"]
+	_, hasErrors := envelope["errors"]
+
+	if isJSON && (hasData || hasErrors) {
+		var gqlRes graphQLResponse
+
+		if err := json.Unmarshal(body, &gqlRes); err != nil {
+			return nil, &messages.InvokeResponse_Error{Message: err.Error(), Type: "BadResponse"}
+		}
+
+		if len(gqlRes.Errors) > 0 {
+			errType := gqlRes.Errors[0].ErrorType
+			if errType == "" {
+				errType = gqlRes.Errors[0].Extensions.Code
+			}
+
+			return nil, &messages.InvokeResponse_Error{Message: gqlRes.Errors[0].Message, Type: errType}
+		}
+
+		return decodeResult(gqlRes.Data[field]), nil
+	}
+
+	if failed(res) {
+		msg := string(body)
+		if msg == "" {
+			msg = http.StatusText(res.StatusCode)
+		}
+
+		return nil, &messages.InvokeResponse_Error{Message: msg, Type: fmt.Sprintf("HTTP%d", res.StatusCode)}
+	}
+
+	if len(body) == 0 {
+		return nil, nil
+	}
+
+	return decodeResult(body), nil
+}
+
+// decodeResult returns raw as a JSON value, or as a string when it isn't valid JSON.
+func decodeResult(raw []byte) any {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	var v any
+
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return string(raw)
+	}
+
+	return v
+}
@@ -0,0 +1,140 @@
+package lamway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/lambda/messages"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/danteay/lamway/request"
+)
+
+func TestAppSyncAdapter(t *testing.T) {
+	evt := request.AppSyncResolverEvent{
+		Arguments: map[string]any{"id": "luna"},
+		Identity:  &request.AppSyncRequestIdentity{Username: "tobi"},
+		Info:      request.AppSyncInfo{ParentTypeName: "Query", FieldName: "getPet", SelectionSetGraphQL: "{ id name }"},
+	}
+
+	t.Run("data", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			b, _ := io.ReadAll(r.Body)
+
+			assert.Equal(t, "/graphql", r.URL.Path)
+			assert.JSONEq(t, `{"query":"query { getPet(id: \"luna\") { id name } }"}`, string(b))
+
+			identity, ok := request.AppSyncIdentity(r.Context())
+			assert.True(t, ok)
+			assert.Equal(t, "tobi", identity.Username)
+
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"data":{"getPet":{"id":"luna","name":"Luna"}}}`))
+		}
+
+		gw := New[request.AppSyncResolverEvent](WithHTTPHandler(http.HandlerFunc(handler)))
+
+		res, err := gw.invoke(context.Background(), evt)
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{"id": "luna", "name": "Luna"}, res)
+	})
+
+	t.Run("custom path and raw body", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/graphql", r.URL.Path)
+
+			_, _ = w.Write([]byte(`{"id":"luna"}`))
+		}
+
+		gw := New[request.AppSyncResolverEvent](
+			WithHTTPHandler(http.HandlerFunc(handler)),
+			WithAdapter[request.AppSyncResolverEvent](AppSyncAdapter{Path: "/api/graphql"}),
+		)
+
+		res, err := gw.invoke(context.Background(), evt)
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{"id": "luna"}, res)
+	})
+
+	t.Run("graphql errors", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"data":null,"errors":[{"message":"pet not found","extensions":{"code":"NOT_FOUND"}}]}`))
+		}
+
+		gw := New[request.AppSyncResolverEvent](WithHTTPHandler(http.HandlerFunc(handler)))
+
+		_, err := gw.invoke(context.Background(), evt)
+
+		// InvokeResponse_Error values are reported by the runtime as they are, so AppSync gets NOT_FOUND as errorType
+		assert.Equal(t, messages.InvokeResponse_Error{Type: "NOT_FOUND", Message: "pet not found"}, err)
+	})
+
+	t.Run("non 2xx status", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}
+
+		gw := New[request.AppSyncResolverEvent](WithHTTPHandler(http.HandlerFunc(handler)))
+
+		_, err := gw.invoke(context.Background(), evt)
+
+		assert.Equal(t, messages.InvokeResponse_Error{Type: "HTTP403", Message: "Forbidden"}, err)
+	})
+}
+
+func TestAppSyncBatchAdapter(t *testing.T) {
+	evts := []request.AppSyncResolverEvent{
+		{Source: map[string]any{"ownerId": "tobi"}, Info: request.AppSyncInfo{ParentTypeName: "Pet", FieldName: "owner"}},
+		{Source: map[string]any{"ownerId": "loki"}, Info: request.AppSyncInfo{ParentTypeName: "Pet", FieldName: "owner"}},
+	}
+
+	// Pet.owner can't be queried from the root, so it's resolved with the owner root field
+	ownerQuery := func(evt request.AppSyncResolverEvent) (string, error) {
+		evt.Info.ParentTypeName = "Query"
+		evt.Arguments = map[string]any{"id": evt.Source["ownerId"]}
+
+		return request.AppSyncQuery(evt)
+	}
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		reqCtx, _ := r.Context().Value(request.ContextKey).(request.AppSyncRequestContext)
+
+		var body struct {
+			Query string `json:"query"`
+		}
+
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, fmt.Sprintf(`query { owner(id: %q) }`, reqCtx.Source["ownerId"]), body.Query)
+
+		if reqCtx.Source["ownerId"] == "loki" {
+			_, _ = w.Write([]byte(`{"errors":[{"message":"owner not found","errorType":"NotFound"}]}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"data":{"owner":{"name":"Tobi"}}}`))
+	}
+
+	gw := New[[]request.AppSyncResolverEvent](
+		WithHTTPHandler(http.HandlerFunc(handler)),
+		WithBatchAdapter[[]request.AppSyncResolverEvent](AppSyncBatchAdapter{Query: ownerQuery, Concurrency: 2}),
+	)
+
+	payload, err := gw.invoke(context.Background(), evts)
+
+	res, errMarshal := json.Marshal(payload)
+	if errMarshal != nil {
+		assert.Fail(t, "can't marshal payload", errMarshal)
+	}
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"data": {"name": "Tobi"}},
+		{"data": null, "errorMessage": "owner not found", "errorType": "NotFound"}
+	]`, string(res))
+}
@@ -0,0 +1,173 @@
+package lamway
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/danteay/lamway/request"
+	"github.com/danteay/lamway/response"
+)
+
+// NewAuthorizer creates a Gateway for API Gateway REST API Lambda authorizers of the REQUEST type. The handler serves
+// the request being authorized, so the auth middleware of the API can be reused, and answers with the policy written
+// by response.WriteAuthorizerPolicy.
+func NewAuthorizer(opts ...Option) *Gateway[events.APIGatewayCustomAuthorizerRequestTypeRequest] {
+	return New[events.APIGatewayCustomAuthorizerRequestTypeRequest](opts...)
+}
+
+// NewTokenAuthorizer creates a Gateway for API Gateway REST API Lambda authorizers of the TOKEN type. The handler
+// serves a request to the method being authorized with the token in the Authorization header, and answers like the
+// handler of NewAuthorizer.
+func NewTokenAuthorizer(opts ...Option) *Gateway[events.APIGatewayCustomAuthorizerRequest] {
+	return New[events.APIGatewayCustomAuthorizerRequest](opts...)
+}
+
+// NewAuthorizerV2 creates a Gateway for API Gateway HTTP API Lambda authorizers using the 2.0 payload format. By
+// default the handler response is returned as a simple response, register an AuthorizerV2Adapter with IAMPolicy set to
+// return IAM policies instead.
+func NewAuthorizerV2(opts ...Option) *Gateway[events.APIGatewayV2CustomAuthorizerV2Request] {
+	return New[events.APIGatewayV2CustomAuthorizerV2Request](opts...)
+}
+
+// AuthorizerAdapter is the built-in adapter for API Gateway REST API Lambda authorizers. A 2xx response must contain
+// an authorizer policy, a 401 rejects the request with ErrUnauthorized, and a 403 without a policy denies the method
+// being authorized.
+type AuthorizerAdapter struct{}
+
+// DecodeRequest implementation.
+func (AuthorizerAdapter) DecodeRequest(ctx context.Context, evt events.APIGatewayCustomAuthorizerRequestTypeRequest) (*http.Request, error) {
+	return request.NewAuthorizer(ctx, evt)
+}
+
+// EncodeResponse implementation.
+func (AuthorizerAdapter) EncodeResponse(_ context.Context, evt events.APIGatewayCustomAuthorizerRequestTypeRequest, res response.APIGatewayResponse) (any, error) {
+	return authorizerResponse(evt.MethodArn, res)
+}
+
+// TokenAuthorizerAdapter is the built-in adapter for API Gateway REST API Lambda authorizers of the TOKEN type. The
+// responses are handled like in AuthorizerAdapter.
+type TokenAuthorizerAdapter struct{}
+
+// DecodeRequest implementation.
+func (TokenAuthorizerAdapter) DecodeRequest(ctx context.Context, evt events.APIGatewayCustomAuthorizerRequest) (*http.Request, error) {
+	return request.NewTokenAuthorizer(ctx, evt)
+}
+
+// EncodeResponse implementation.
+func (TokenAuthorizerAdapter) EncodeResponse(_ context.Context, evt events.APIGatewayCustomAuthorizerRequest, res response.APIGatewayResponse) (any, error) {
+	return authorizerResponse(evt.MethodArn, res)
+}
+
+// authorizerResponse translates the handler response of a REST API authorizer into its policy.
+func authorizerResponse(methodARN string, res response.APIGatewayResponse) (any, error) {
+	policy, ok := authorizerPolicy(res)
+
+	switch {
+	case res.StatusCode >= 200 && res.StatusCode <= 299 && ok:
+		return policy, nil
+	case res.StatusCode == http.StatusUnauthorized:
+		return nil, ErrUnauthorized
+	case res.StatusCode == http.StatusForbidden && ok:
+		return policy, nil
+	case res.StatusCode == http.StatusForbidden:
+		return response.AuthorizerPolicy("", response.EffectDeny, nil, methodARN), nil
+	default:
+		return nil, fmt.Errorf("%w: status %d", ErrInvalidAuthorizerResponse, res.StatusCode)
+	}
+}
+
+// authorizerPolicy decodes the policy written by the handler.
+func authorizerPolicy(res response.APIGatewayResponse) (events.APIGatewayCustomAuthorizerResponse, bool) {
+	var policy events.APIGatewayCustomAuthorizerResponse
+
+	if err := json.Unmarshal(authorizerBody(res), &policy); err != nil || len(policy.PolicyDocument.Statement) == 0 {
+		return events.APIGatewayCustomAuthorizerResponse{}, false
+	}
+
+	return policy, true
+}
+
+// authorizerContext decodes the JSON object written by the handler, returning nil for any other body.
+func authorizerContext(res response.APIGatewayResponse) map[string]any {
+	var authCtx map[string]any
+
+	if err := json.Unmarshal(authorizerBody(res), &authCtx); err != nil {
+		return nil
+	}
+
+	return authCtx
+}
+
+func authorizerBody(res response.APIGatewayResponse) []byte {
+	if !res.IsBase64Encoded {
+		return []byte(res.Body)
+	}
+
+	b, err := base64.StdEncoding.DecodeString(res.Body)
+	if err != nil {
+		return nil
+	}
+
+	return b
+}
+
+// AuthorizerV2Adapter is the built-in adapter for API Gateway HTTP API Lambda authorizers using the 2.0 payload
+// format. A 2xx response authorizes the request and a 403 denies it, with the JSON object written by the handler, if
+// any, as the authorizer context. A 401 rejects the request with ErrUnauthorized.
+//
+// When IAMPolicy is true, the authorizer answers with an IAM policy instead of a simple response: the policy written by
+// response.WriteAuthorizerPolicy, or one allowing or denying the route being authorized.
+type AuthorizerV2Adapter struct {
+	IAMPolicy bool
+}
+
+// DecodeRequest implementation.
+func (AuthorizerV2Adapter) DecodeRequest(ctx context.Context, evt events.APIGatewayV2CustomAuthorizerV2Request) (*http.Request, error) {
+	return request.NewAuthorizerV2(ctx, evt)
+}
+
+// EncodeResponse implementation.
+func (a AuthorizerV2Adapter) EncodeResponse(_ context.Context, evt events.APIGatewayV2CustomAuthorizerV2Request, res response.APIGatewayResponse) (any, error) {
+	authorized := !failed(res)
+
+	if !authorized && res.StatusCode != http.StatusForbidden {
+		if res.StatusCode == http.StatusUnauthorized {
+			return nil, ErrUnauthorized
+		}
+
+		return nil, fmt.Errorf("%w: status %d", ErrInvalidAuthorizerResponse, res.StatusCode)
+	}
+
+	if !a.IAMPolicy {
+		return events.APIGatewayV2CustomAuthorizerSimpleResponse{
+			IsAuthorized: authorized,
+			Context:      authorizerContext(res),
+		}, nil
+	}
+
+	if policy, ok := authorizerPolicy(res); ok {
+		return events.APIGatewayV2CustomAuthorizerIAMPolicyResponse{
+			PrincipalID:    policy.PrincipalID,
+			PolicyDocument: policy.PolicyDocument,
+			Context:        policy.Context,
+		}, nil
+	}
+
+	effect := response.EffectDeny
+	if authorized {
+		effect = response.EffectAllow
+	}
+
+	policy := response.AuthorizerPolicy("", effect, authorizerContext(res), evt.RouteArn)
+
+	return events.APIGatewayV2CustomAuthorizerIAMPolicyResponse{
+		PrincipalID:    policy.PrincipalID,
+		PolicyDocument: policy.PolicyDocument,
+		Context:        policy.Context,
+	}, nil
+}
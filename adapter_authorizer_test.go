@@ -0,0 +1,239 @@
+package lamway
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/danteay/lamway/request"
+	"github.com/danteay/lamway/response"
+)
+
+func TestNewAuthorizer(t *testing.T) {
+	const methodARN = "arn:aws:execute-api:us-east-1:123456789012:abcdef123/prod/GET/pets/luna"
+
+	// auth middleware shared with the API
+	requireToken := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Header.Get("Authorization") {
+			case "":
+				w.WriteHeader(http.StatusUnauthorized)
+			case "Bearer blocked":
+				w.WriteHeader(http.StatusForbidden)
+			default:
+				next.ServeHTTP(w, r)
+			}
+		})
+	}
+
+	handler := requireToken(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		arn, ok := request.MethodARN(r.Context())
+
+		assert.True(t, ok)
+		assert.Equal(t, methodARN, r.Header.Get(request.HeaderMethodARN))
+		assert.Equal(t, "/pets/luna", r.URL.Path)
+		assert.Equal(t, "desc", r.URL.Query().Get("order"))
+
+		policy := response.AuthorizerPolicy("user-1", response.EffectAllow, map[string]any{"role": "admin"}, arn)
+
+		assert.NoError(t, response.WriteAuthorizerPolicy(w, policy))
+	}))
+
+	gw := NewAuthorizer(WithHTTPHandler(handler))
+
+	newEvent := func(token string) events.APIGatewayCustomAuthorizerRequestTypeRequest {
+		return events.APIGatewayCustomAuthorizerRequestTypeRequest{
+			Type:                  "REQUEST",
+			MethodArn:             methodARN,
+			Path:                  "/pets/luna",
+			HTTPMethod:            http.MethodGet,
+			Headers:               map[string]string{"Authorization": token},
+			QueryStringParameters: map[string]string{"order": "desc"},
+			RequestContext:        events.APIGatewayCustomAuthorizerRequestTypeRequestContext{Stage: "prod"},
+		}
+	}
+
+	t.Run("allow", func(t *testing.T) {
+		res, err := gw.invoke(context.Background(), newEvent("Bearer valid"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, events.APIGatewayCustomAuthorizerResponse{
+			PrincipalID: "user-1",
+			PolicyDocument: events.APIGatewayCustomAuthorizerPolicy{
+				Version: "2012-10-17",
+				Statement: []events.IAMPolicyStatement{
+					{Action: []string{"execute-api:Invoke"}, Effect: "Allow", Resource: []string{methodARN}},
+				},
+			},
+			Context: map[string]any{"role": "admin"},
+		}, res)
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		_, err := gw.invoke(context.Background(), newEvent(""))
+
+		assert.ErrorIs(t, err, ErrUnauthorized)
+		assert.Equal(t, "Unauthorized", err.Error())
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		res, err := gw.invoke(context.Background(), newEvent("Bearer blocked"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, response.AuthorizerPolicy("", response.EffectDeny, nil, methodARN), res)
+	})
+
+	t.Run("missing policy", func(t *testing.T) {
+		gw := NewAuthorizer(WithHTTPHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("ok"))
+		})))
+
+		_, err := gw.invoke(context.Background(), newEvent("Bearer valid"))
+
+		assert.ErrorIs(t, err, ErrInvalidAuthorizerResponse)
+	})
+}
+
+func TestNewTokenAuthorizer(t *testing.T) {
+	const methodARN = "arn:aws:execute-api:us-east-1:123456789012:abcdef123/prod/DELETE/pets/luna"
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		arn, ok := request.MethodARN(r.Context())
+
+		assert.True(t, ok)
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/pets/luna", r.URL.Path)
+
+		switch r.Header.Get("Authorization") {
+		case "Bearer valid":
+			assert.NoError(t, response.WriteAuthorizerPolicy(w, response.AuthorizerPolicy("user-1", response.EffectAllow, nil, arn)))
+		case "Bearer blocked":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}
+
+	gw := NewTokenAuthorizer(WithHTTPHandler(http.HandlerFunc(handler)))
+
+	newEvent := func(token string) events.APIGatewayCustomAuthorizerRequest {
+		return events.APIGatewayCustomAuthorizerRequest{Type: "TOKEN", AuthorizationToken: token, MethodArn: methodARN}
+	}
+
+	t.Run("allow", func(t *testing.T) {
+		res, err := gw.invoke(context.Background(), newEvent("Bearer valid"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, response.AuthorizerPolicy("user-1", response.EffectAllow, nil, methodARN), res)
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		_, err := gw.invoke(context.Background(), newEvent(""))
+
+		assert.ErrorIs(t, err, ErrUnauthorized)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		res, err := gw.invoke(context.Background(), newEvent("Bearer blocked"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, response.AuthorizerPolicy("", response.EffectDeny, nil, methodARN), res)
+	})
+}
+
+func TestNewAuthorizerV2(t *testing.T) {
+	const routeARN = "arn:aws:execute-api:us-east-1:123456789012:abcdef123/$default/GET/pets"
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		arn, _ := request.MethodARN(r.Context())
+		assert.Equal(t, routeARN, arn)
+		assert.Equal(t, "/pets", r.URL.Path)
+
+		session, err := r.Cookie("session")
+		if err != nil || session.Value == "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		if session.Value == "blocked" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"userId":"user-1"}`))
+	}
+
+	newEvent := func(cookies ...string) events.APIGatewayV2CustomAuthorizerV2Request {
+		return events.APIGatewayV2CustomAuthorizerV2Request{
+			Version:  "2.0",
+			Type:     "REQUEST",
+			RouteArn: routeARN,
+			RawPath:  "/pets",
+			Cookies:  cookies,
+			RequestContext: events.APIGatewayV2HTTPRequestContext{
+				HTTP: events.APIGatewayV2HTTPRequestContextHTTPDescription{Method: http.MethodGet},
+			},
+		}
+	}
+
+	t.Run("simple response", func(t *testing.T) {
+		gw := NewAuthorizerV2(WithHTTPHandler(http.HandlerFunc(handler)))
+
+		res, err := gw.invoke(context.Background(), newEvent("session=valid"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, events.APIGatewayV2CustomAuthorizerSimpleResponse{
+			IsAuthorized: true,
+			Context:      map[string]any{"userId": "user-1"},
+		}, res)
+
+		res, err = gw.invoke(context.Background(), newEvent("session=blocked"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, events.APIGatewayV2CustomAuthorizerSimpleResponse{IsAuthorized: false}, res)
+
+		_, err = gw.invoke(context.Background(), newEvent())
+
+		assert.ErrorIs(t, err, ErrUnauthorized)
+	})
+
+	t.Run("iam policy", func(t *testing.T) {
+		gw := NewAuthorizerV2(
+			WithHTTPHandler(http.HandlerFunc(handler)),
+			WithAdapter[events.APIGatewayV2CustomAuthorizerV2Request](AuthorizerV2Adapter{IAMPolicy: true}),
+		)
+
+		res, err := gw.invoke(context.Background(), newEvent("session=valid"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, events.APIGatewayV2CustomAuthorizerIAMPolicyResponse{
+			PolicyDocument: events.APIGatewayCustomAuthorizerPolicy{
+				Version: "2012-10-17",
+				Statement: []events.IAMPolicyStatement{
+					{Action: []string{"execute-api:Invoke"}, Effect: "Allow", Resource: []string{routeARN}},
+				},
+			},
+			Context: map[string]any{"userId": "user-1"},
+		}, res)
+
+		res, err = gw.invoke(context.Background(), newEvent("session=blocked"))
+
+		policy, _ := res.(events.APIGatewayV2CustomAuthorizerIAMPolicyResponse)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Deny", policy.PolicyDocument.Statement[0].Effect)
+	})
+
+	t.Run("silent handler", func(t *testing.T) {
+		gw := NewAuthorizerV2(WithHTTPHandler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})))
+
+		res, err := gw.invoke(context.Background(), newEvent("session=valid"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, events.APIGatewayV2CustomAuthorizerSimpleResponse{IsAuthorized: true}, res)
+	})
+}
@@ -0,0 +1,62 @@
+package lamway
+
+import (
+	"context"
+	"encoding/base64"
+	"mime"
+	"net/http"
+
+	"github.com/danteay/lamway/request"
+	"github.com/danteay/lamway/response"
+)
+
+// BedrockAgentAdapter is the built-in adapter for Bedrock agent action groups defined with an OpenAPI schema. The
+// `apiPath` and `httpMethod` of the event are served as a regular request, and the handler response is wrapped in the
+// `response.responseBody` envelope the agent expects.
+type BedrockAgentAdapter struct{}
+
+// DecodeRequest implementation.
+func (BedrockAgentAdapter) DecodeRequest(ctx context.Context, evt request.BedrockAgentEvent) (*http.Request, error) {
+	return request.NewBedrockAgent(ctx, evt)
+}
+
+// EncodeResponse implementation.
+func (BedrockAgentAdapter) EncodeResponse(_ context.Context, evt request.BedrockAgentEvent, res response.APIGatewayResponse) (any, error) {
+	// agents read application/json bodies, text/plain is only the writer default when the handler didn't set one
+	contentType := "application/json"
+	if mt, _, err := mime.ParseMediaType(res.Headers["Content-Type"]); err == nil && mt != "text/plain" {
+		contentType = mt
+	}
+
+	// like failed, a handler that doesn't write anything answered with a 200
+	status := res.StatusCode
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	body := res.Body
+
+	if res.IsBase64Encoded {
+		b, err := base64.StdEncoding.DecodeString(res.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		body = string(b)
+	}
+
+	return map[string]any{
+		"messageVersion": "1.0",
+		"response": map[string]any{
+			"actionGroup":    evt.ActionGroup,
+			"apiPath":        evt.APIPath,
+			"httpMethod":     evt.HTTPMethod,
+			"httpStatusCode": status,
+			"responseBody": map[string]any{
+				contentType: map[string]any{"body": body},
+			},
+		},
+		"sessionAttributes":       evt.SessionAttributes,
+		"promptSessionAttributes": evt.PromptSessionAttributes,
+	}, nil
+}
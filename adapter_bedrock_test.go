@@ -0,0 +1,91 @@
+package lamway
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/danteay/lamway/request"
+)
+
+func TestBedrockAgentAdapter(t *testing.T) {
+	evt := request.BedrockAgentEvent{
+		MessageVersion: "1.0",
+		Agent:          request.BedrockAgent{Name: "pets-agent", ID: "AGENT1"},
+		SessionID:      "session-1",
+		ActionGroup:    "pets",
+		APIPath:        "/pets/{petId}/visits",
+		HTTPMethod:     http.MethodPost,
+		Parameters: []request.BedrockAgentParameter{
+			{Name: "petId", Type: "string", Value: "luna"},
+			{Name: "notify", Type: "boolean", Value: "true"},
+		},
+		RequestBody: &request.BedrockAgentRequestBody{
+			Content: map[string]request.BedrockAgentContent{
+				"application/json": {
+					Properties: []request.BedrockAgentParameter{
+						{Name: "reason", Type: "string", Value: "checkup"},
+						{Name: "weight", Type: "number", Value: "4.5"},
+					},
+				},
+			},
+		},
+		SessionAttributes: map[string]string{"owner": "tobi"},
+	}
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+
+		assert.Equal(t, "/pets/luna/visits", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("notify"))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.JSONEq(t, `{"reason":"checkup","weight":4.5}`, string(b))
+
+		reqCtx, ok := r.Context().Value(request.ContextKey).(request.BedrockAgentContext)
+		assert.True(t, ok)
+		assert.Equal(t, "pets-agent", reqCtx.Agent.Name)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"visit":"v-1"}`))
+	}
+
+	gw := New[request.BedrockAgentEvent](WithHTTPHandler(http.HandlerFunc(handler)))
+
+	payload, err := gw.invoke(context.Background(), evt)
+
+	res, errMarshal := json.Marshal(payload)
+	if errMarshal != nil {
+		assert.Fail(t, "can't marshal payload", errMarshal)
+	}
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"messageVersion": "1.0",
+		"response": {
+			"actionGroup": "pets",
+			"apiPath": "/pets/{petId}/visits",
+			"httpMethod": "POST",
+			"httpStatusCode": 201,
+			"responseBody": {"application/json": {"body": "{\"visit\":\"v-1\"}"}}
+		},
+		"sessionAttributes": {"owner": "tobi"},
+		"promptSessionAttributes": null
+	}`, string(res))
+}
+
+func TestBedrockAgentAdapter_silentHandler(t *testing.T) {
+	gw := New[request.BedrockAgentEvent](WithHTTPHandler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})))
+
+	payload, err := gw.invoke(context.Background(), request.BedrockAgentEvent{ActionGroup: "pets", APIPath: "/pets", HTTPMethod: http.MethodGet})
+
+	res, _ := payload.(map[string]any)["response"].(map[string]any)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res["httpStatusCode"])
+	assert.Equal(t, map[string]any{"application/json": map[string]any{"body": ""}}, res["responseBody"])
+}
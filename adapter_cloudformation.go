@@ -0,0 +1,155 @@
+package lamway
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-lambda-go/cfn"
+	"github.com/aws/aws-lambda-go/lambdacontext"
+
+	"github.com/danteay/lamway/request"
+	"github.com/danteay/lamway/response"
+)
+
+const (
+	defaultCloudFormationPath = "/"
+	defaultUploadRetries      = 3
+	defaultUploadRetryDelay   = time.Second
+)
+
+// CloudFormationAdapter is the built-in adapter for CloudFormation custom resources. The Create, Update and Delete
+// requests are served as a POST, PUT and DELETE to Path, "/" by default, and the outcome is uploaded to the
+// pre-signed ResponseURL of the event.
+//
+// A 2xx response succeeds, with the JSON object written by the handler, if any, as the resource Data, and the
+// X-Physical-Resource-Id response header as the physical ID of the resource. When the header isn't set the ID sent by
+// CloudFormation, or the log stream name on Create, is used. Any other status code fails the request with the body as
+// the reason.
+//
+// The upload is retried up to Retries times, 3 by default, waiting RetryDelay, 1 second by default, between attempts.
+type CloudFormationAdapter struct {
+	Path       string
+	Client     *http.Client
+	Retries    int
+	RetryDelay time.Duration
+}
+
+// DecodeRequest implementation.
+func (a CloudFormationAdapter) DecodeRequest(ctx context.Context, evt cfn.Event) (*http.Request, error) {
+	return request.NewCloudFormation(ctx, evt, pathOrDefault(a.Path, defaultCloudFormationPath))
+}
+
+// EncodeResponse implementation. It's also called with the default error response when the request can't be built,
+// so CloudFormation is always notified instead of waiting for the timeout.
+func (a CloudFormationAdapter) EncodeResponse(ctx context.Context, evt cfn.Event, res response.APIGatewayResponse) (any, error) {
+	out := cfn.NewResponse(&evt)
+	out.PhysicalResourceID = cloudFormationPhysicalID(evt, res)
+
+	body := []byte(res.Body)
+
+	if res.IsBase64Encoded {
+		if b, err := base64.StdEncoding.DecodeString(res.Body); err == nil {
+			body = b
+		}
+	}
+
+	if !failed(res) {
+		out.Status = cfn.StatusSuccess
+
+		// non object bodies, like the text/plain default, aren't resource data
+		_ = json.Unmarshal(body, &out.Data)
+	} else {
+		out.Status = cfn.StatusFailed
+		out.Reason = strings.TrimSpace(string(body))
+
+		if out.Reason == "" {
+			out.Reason = fmt.Sprintf("handler answered with status %d", res.StatusCode)
+		}
+	}
+
+	return nil, a.upload(ctx, evt.ResponseURL, out)
+}
+
+// upload sends the response to the pre-signed url, retrying failed attempts.
+func (a CloudFormationAdapter) upload(ctx context.Context, url string, res *cfn.Response) error {
+	body, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+
+	retries := a.Retries
+	if retries <= 0 {
+		retries = defaultUploadRetries
+	}
+
+	delay := a.RetryDelay
+	if delay <= 0 {
+		delay = defaultUploadRetryDelay
+	}
+
+	client := a.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	for attempt := 0; ; attempt++ {
+		err = putResponse(ctx, client, url, body)
+		if err == nil || attempt >= retries {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+	}
+}
+
+func putResponse(ctx context.Context, client *http.Client, url string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	// the url is signed without a content type
+	req.Header.Del("Content-Type")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	b, _ := io.ReadAll(res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("%w: %d %s", ErrResponseUploadFailed, res.StatusCode, strings.TrimSpace(string(b)))
+	}
+
+	return nil
+}
+
+func cloudFormationPhysicalID(evt cfn.Event, res response.APIGatewayResponse) string {
+	for k, v := range res.Headers {
+		if strings.EqualFold(k, request.HeaderPhysicalResourceID) && v != "" {
+			return v
+		}
+	}
+
+	if evt.PhysicalResourceID != "" {
+		return evt.PhysicalResourceID
+	}
+
+	return lambdacontext.LogStreamName
+}
@@ -0,0 +1,131 @@
+package lamway
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/cfn"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/danteay/lamway/request"
+)
+
+func TestCloudFormationAdapter(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		var props map[string]any
+
+		_ = json.NewDecoder(r.Body).Decode(&props)
+
+		switch r.Method {
+		case http.MethodPost:
+			assert.Equal(t, "pets", props["Name"])
+
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set(request.HeaderPhysicalResourceID, "bucket-pets")
+			_, _ = w.Write([]byte(`{"Arn":"arn:aws:s3:::bucket-pets"}`))
+		case http.MethodDelete:
+			assert.Equal(t, "bucket-pets", r.Header.Get(request.HeaderPhysicalResourceID))
+
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte("bucket not empty"))
+		}
+	}
+
+	t.Run("create", func(t *testing.T) {
+		var uploaded cfn.Response
+
+		attempts := int32(0)
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// the first upload fails to exercise the retries
+			if atomic.AddInt32(&attempts, 1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+
+			b, _ := io.ReadAll(r.Body)
+
+			assert.Equal(t, http.MethodPut, r.Method)
+			assert.Empty(t, r.Header.Get("Content-Type"))
+			assert.NoError(t, json.Unmarshal(b, &uploaded))
+		}))
+		defer srv.Close()
+
+		gw := New[cfn.Event](
+			WithHTTPHandler(http.HandlerFunc(handler)),
+			WithAdapter[cfn.Event](CloudFormationAdapter{Path: "/resources/buckets", RetryDelay: time.Millisecond}),
+		)
+
+		_, err := gw.invoke(context.Background(), cfn.Event{
+			RequestType:        cfn.RequestCreate,
+			RequestID:          "req-1",
+			ResponseURL:        srv.URL + "/signed",
+			LogicalResourceID:  "PetsBucket",
+			StackID:            "stack-1",
+			ResourceProperties: map[string]any{"Name": "pets"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+		assert.Equal(t, cfn.Response{
+			Status:             cfn.StatusSuccess,
+			RequestID:          "req-1",
+			LogicalResourceID:  "PetsBucket",
+			StackID:            "stack-1",
+			PhysicalResourceID: "bucket-pets",
+			Data:               map[string]any{"Arn": "arn:aws:s3:::bucket-pets"},
+		}, uploaded)
+	})
+
+	t.Run("failed delete", func(t *testing.T) {
+		var uploaded cfn.Response
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&uploaded))
+		}))
+		defer srv.Close()
+
+		gw := New[cfn.Event](WithHTTPHandler(http.HandlerFunc(handler)))
+
+		_, err := gw.invoke(context.Background(), cfn.Event{
+			RequestType:        cfn.RequestDelete,
+			ResponseURL:        srv.URL,
+			PhysicalResourceID: "bucket-pets",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, cfn.StatusFailed, uploaded.Status)
+		assert.Equal(t, "bucket not empty", uploaded.Reason)
+		assert.Equal(t, "bucket-pets", uploaded.PhysicalResourceID)
+	})
+
+	t.Run("upload fails", func(t *testing.T) {
+		attempts := int32(0)
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&attempts, 1)
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer srv.Close()
+
+		gw := New[cfn.Event](
+			WithHTTPHandler(http.HandlerFunc(handler)),
+			WithAdapter[cfn.Event](CloudFormationAdapter{Retries: 2, RetryDelay: time.Millisecond}),
+		)
+
+		_, err := gw.invoke(context.Background(), cfn.Event{
+			RequestType:        cfn.RequestCreate,
+			ResponseURL:        srv.URL,
+			ResourceProperties: map[string]any{"Name": "pets"},
+		})
+
+		assert.ErrorIs(t, err, ErrResponseUploadFailed)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+	})
+}
@@ -0,0 +1,28 @@
+package lamway
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/danteay/lamway/request"
+	"github.com/danteay/lamway/response"
+)
+
+// CloudFrontAdapter is the built-in adapter for Lambda@Edge viewer-request and origin-request events. The handler
+// response is returned as a generated response, unless the handler calls request.ForwardToOrigin to let the,
+// maybe modified, request continue to the origin.
+type CloudFrontAdapter struct{}
+
+// DecodeRequest implementation.
+func (CloudFrontAdapter) DecodeRequest(ctx context.Context, evt request.CloudFrontEvent) (*http.Request, error) {
+	return request.NewCloudFront(ctx, evt)
+}
+
+// EncodeResponse implementation.
+func (CloudFrontAdapter) EncodeResponse(ctx context.Context, evt request.CloudFrontEvent, res response.APIGatewayResponse) (any, error) {
+	if fwd, ok := request.CloudFrontForwarded(ctx, evt); ok {
+		return fwd, nil
+	}
+
+	return res.ToCloudFrontMap(), nil
+}
@@ -0,0 +1,101 @@
+package lamway
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/danteay/lamway/request"
+)
+
+func newCloudFrontEvent() request.CloudFrontEvent {
+	return request.CloudFrontEvent{
+		Records: []request.CloudFrontRecord{
+			{
+				CF: request.CloudFrontRecordData{
+					Config: request.CloudFrontConfig{
+						DistributionDomainName: "d111111abcdef8.cloudfront.net",
+						EventType:              "viewer-request",
+						RequestID:              "1234",
+					},
+					Request: request.CloudFrontRequest{
+						ClientIP:    "203.0.113.178",
+						Method:      http.MethodGet,
+						URI:         testPath,
+						Querystring: "size=large",
+						Headers: request.CloudFrontHeaders{
+							"host":       {{Key: "Host", Value: "d111111abcdef8.cloudfront.net"}},
+							"user-agent": {{Key: "User-Agent", Value: "curl/8.0"}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestCloudFrontAdapter(t *testing.T) {
+	t.Run("should return generated response", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") == "" {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				w.WriteHeader(http.StatusUnauthorized)
+
+				return
+			}
+		}
+
+		gw := New[request.CloudFrontEvent](WithHTTPHandler(http.HandlerFunc(handler)))
+
+		payload, err := gw.invoke(context.Background(), newCloudFrontEvent())
+
+		res, errMarshal := json.Marshal(payload)
+		if errMarshal != nil {
+			assert.Fail(t, "can't marshal payload", errMarshal)
+		}
+
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"status":"401", "statusDescription":"Unauthorized", "bodyEncoding":"text", "body":"", "headers":{"content-type":[{"key":"Content-Type", "value":"text/plain; charset=utf8"}], "www-authenticate":[{"key":"Www-Authenticate", "value":"Bearer"}]}}`, string(res))
+	})
+
+	t.Run("should forward modified request to origin", func(t *testing.T) {
+		handler := func(_ http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, testPath, r.URL.Path)
+			assert.Equal(t, "203.0.113.178", r.RemoteAddr)
+			assert.Equal(t, "d111111abcdef8.cloudfront.net", r.Host)
+
+			r.URL.Path = "/v2" + r.URL.Path
+			r.Header.Set("X-Device", "desktop")
+			r.Header.Del("User-Agent")
+
+			assert.True(t, request.ForwardToOrigin(r))
+		}
+
+		gw := New[request.CloudFrontEvent](WithHTTPHandler(http.HandlerFunc(handler)))
+
+		payload, err := gw.invoke(context.Background(), newCloudFrontEvent())
+
+		assert.NoError(t, err)
+		assert.Equal(t, request.CloudFrontRequest{
+			ClientIP:    "203.0.113.178",
+			Method:      http.MethodGet,
+			URI:         "/v2" + testPath,
+			Querystring: "size=large",
+			Headers: request.CloudFrontHeaders{
+				"host":     {{Key: "Host", Value: "d111111abcdef8.cloudfront.net"}},
+				"x-device": {{Key: "X-Device", Value: "desktop"}},
+			},
+		}, payload)
+	})
+
+	t.Run("should fail without records", func(t *testing.T) {
+		gw := New[request.CloudFrontEvent](WithHTTPHandler(http.HandlerFunc(hello)))
+
+		_, err := gw.invoke(context.Background(), request.CloudFrontEvent{})
+
+		assert.ErrorIs(t, err, request.ErrEventWithoutRecords)
+	})
+}
@@ -0,0 +1,23 @@
+package lamway
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/danteay/lamway/request"
+	"github.com/danteay/lamway/response"
+)
+
+// DirectInvokeAdapter is the built-in adapter for request.DirectInvokeEvent, the lamway envelope for functions
+// invoked directly by other services. The response is a response.DirectInvokeResponse.
+type DirectInvokeAdapter struct{}
+
+// DecodeRequest implementation.
+func (DirectInvokeAdapter) DecodeRequest(ctx context.Context, evt request.DirectInvokeEvent) (*http.Request, error) {
+	return request.NewDirectInvoke(ctx, evt)
+}
+
+// EncodeResponse implementation.
+func (DirectInvokeAdapter) EncodeResponse(_ context.Context, _ request.DirectInvokeEvent, res response.APIGatewayResponse) (any, error) {
+	return res.ToDirectInvoke(), nil
+}
@@ -0,0 +1,55 @@
+package lamway
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/danteay/lamway/request"
+	"github.com/danteay/lamway/response"
+)
+
+func TestDirectInvokeAdapter(t *testing.T) {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/pets", func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "true", r.URL.Query().Get("notify"))
+		assert.JSONEq(t, `{"name":"luna"}`, string(b))
+
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "1234"})
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"luna"}`))
+	})
+
+	gw := New[request.DirectInvokeEvent](WithHTTPHandler(mux))
+
+	res, err := gw.invoke(context.Background(), request.DirectInvokeEvent{
+		Method:  http.MethodPost,
+		Path:    "/pets?notify=true",
+		Headers: map[string]string{"Content-Type": "application/json"},
+		Body:    `{"name":"luna"}`,
+	})
+
+	assert.NoError(t, err)
+
+	directRes, ok := res.(response.DirectInvokeResponse)
+	if !ok {
+		t.Fatalf("unexpected response type %T", res)
+	}
+
+	body, err := directRes.DecodeBody()
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, directRes.StatusCode)
+	assert.Equal(t, "application/json", directRes.Headers["Content-Type"])
+	assert.NotContains(t, directRes.Headers, "Set-Cookie")
+	assert.Equal(t, []string{"session=1234"}, directRes.Cookies)
+	assert.JSONEq(t, `{"id":"luna"}`, string(body))
+}
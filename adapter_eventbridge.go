@@ -0,0 +1,75 @@
+package lamway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/danteay/lamway/request"
+	"github.com/danteay/lamway/response"
+)
+
+// EventBridgeRoute maps the EventBridge events matching all its non empty fields to Path.
+type EventBridgeRoute struct {
+	Source     string
+	DetailType string
+	// Rule is the name of the rule that sent the event, e.g. the schedule of a cron job.
+	Rule string
+	Path string
+}
+
+// EventBridgeAdapter is the built-in adapter for EventBridge and scheduled events. Every event is served as a POST
+// request to the path of the first matching route, with the event detail as the JSON body, so scheduled jobs can be
+// regular endpoints of the router.
+//
+// Events without a matching route fail with ErrNoRoute, and non 2xx responses with ErrHandlerFailed, so EventBridge
+// retries them.
+type EventBridgeAdapter struct {
+	Routes []EventBridgeRoute
+}
+
+// DecodeRequest implementation.
+func (a EventBridgeAdapter) DecodeRequest(ctx context.Context, evt events.EventBridgeEvent) (*http.Request, error) {
+	for _, route := range a.Routes {
+		if route.matches(evt) {
+			return request.NewEventBridge(ctx, evt, route.Path)
+		}
+	}
+
+	return nil, fmt.Errorf("%w: source %q, detail-type %q", ErrNoRoute, evt.Source, evt.DetailType)
+}
+
+// EncodeResponse implementation.
+func (EventBridgeAdapter) EncodeResponse(_ context.Context, _ events.EventBridgeEvent, res response.APIGatewayResponse) (any, error) {
+	if failed(res) {
+		return nil, fmt.Errorf("%w: status %d: %s", ErrHandlerFailed, res.StatusCode, res.Body)
+	}
+
+	return nil, nil
+}
+
+func (r EventBridgeRoute) matches(evt events.EventBridgeEvent) bool {
+	if r.Source != "" && r.Source != evt.Source {
+		return false
+	}
+
+	if r.DetailType != "" && r.DetailType != evt.DetailType {
+		return false
+	}
+
+	if r.Rule == "" {
+		return true
+	}
+
+	// rule ARNs are arn:aws:events:<region>:<account>:rule/[<event-bus>/]<name>
+	for _, resource := range evt.Resources {
+		if _, rule, ok := strings.Cut(resource, ":rule/"); ok && rule[strings.LastIndex(rule, "/")+1:] == r.Rule {
+			return true
+		}
+	}
+
+	return false
+}
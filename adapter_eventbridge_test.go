@@ -0,0 +1,86 @@
+package lamway
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/danteay/lamway/request"
+)
+
+func TestEventBridgeAdapter(t *testing.T) {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/internal/jobs/cleanup", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "evt-1", r.Header.Get(request.HeaderEventID))
+		assert.Equal(t, "2024-01-02T03:04:05Z", r.Header.Get(request.HeaderEventTime))
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.HandleFunc("/internal/orders/created", func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.JSONEq(t, `{"orderId":"o-1"}`, string(b))
+
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	gw := New[events.EventBridgeEvent](
+		WithHTTPHandler(mux),
+		WithAdapter[events.EventBridgeEvent](EventBridgeAdapter{
+			Routes: []EventBridgeRoute{
+				{Rule: "nightly-cleanup", Path: "/internal/jobs/cleanup"},
+				{Source: "shop.orders", DetailType: "OrderCreated", Path: "/internal/orders/created"},
+			},
+		}),
+	)
+
+	t.Run("scheduled event", func(t *testing.T) {
+		evt := events.EventBridgeEvent{
+			ID:         "evt-1",
+			Source:     "aws.events",
+			DetailType: "Scheduled Event",
+			Time:       time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			Resources:  []string{"arn:aws:events:us-east-1:123456789012:rule/nightly-cleanup"},
+			Detail:     json.RawMessage(`{}`),
+		}
+
+		_, err := gw.invoke(context.Background(), evt)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("non 2xx response", func(t *testing.T) {
+		evt := events.EventBridgeEvent{
+			ID:         "evt-2",
+			Source:     "shop.orders",
+			DetailType: "OrderCreated",
+			Detail:     json.RawMessage(`{"orderId":"o-1"}`),
+		}
+
+		_, err := gw.invoke(context.Background(), evt)
+
+		assert.ErrorIs(t, err, ErrHandlerFailed)
+	})
+
+	t.Run("no route", func(t *testing.T) {
+		evt := events.EventBridgeEvent{
+			Source:     "shop.orders",
+			DetailType: "OrderShipped",
+			Resources:  []string{"arn:aws:events:us-east-1:123456789012:rule/custom-bus/orders"},
+		}
+
+		_, err := gw.invoke(context.Background(), evt)
+
+		assert.ErrorIs(t, err, ErrNoRoute)
+	})
+}
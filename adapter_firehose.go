@@ -0,0 +1,81 @@
+package lamway
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/danteay/lamway/request"
+)
+
+const defaultFirehosePath = "/"
+
+// FirehoseAdapter is the built-in adapter for Kinesis Data Firehose record transformations. Every record is served as
+// a POST request to Path, "/" by default, up to Concurrency at the same time, and the response body is returned as the
+// transformed data.
+//
+// A 204 drops the record, any other 2xx marks it as Ok and the rest of status codes as ProcessingFailed, keeping the
+// original data so it's delivered to the error output.
+type FirehoseAdapter struct {
+	Path        string
+	Concurrency int
+}
+
+// DecodeBatch implementation.
+func (a FirehoseAdapter) DecodeBatch(ctx context.Context, evt events.KinesisFirehoseEvent) (Batch, error) {
+	batch := Batch{
+		Requests:    make([]BatchRequest, 0, len(evt.Records)),
+		Concurrency: a.Concurrency,
+	}
+
+	for _, record := range evt.Records {
+		req, err := request.NewFirehose(ctx, record, pathOrDefault(a.Path, defaultFirehosePath))
+		if err != nil {
+			return Batch{}, err
+		}
+
+		batch.Requests = append(batch.Requests, BatchRequest{ID: record.RecordID, Request: req})
+	}
+
+	return batch, nil
+}
+
+// EncodeBatch implementation.
+func (FirehoseAdapter) EncodeBatch(_ context.Context, evt events.KinesisFirehoseEvent, results []BatchResult) (any, error) {
+	res := events.KinesisFirehoseResponse{Records: make([]events.KinesisFirehoseResponseRecord, 0, len(results))}
+
+	for i, result := range results {
+		record := events.KinesisFirehoseResponseRecord{
+			RecordID: result.ID,
+			Result:   events.KinesisFirehoseTransformedStateProcessingFailed,
+			Data:     evt.Records[i].Data,
+		}
+
+		switch {
+		case result.Failed():
+			// delivered to the error output with the original data
+		case result.Response.StatusCode == http.StatusNoContent:
+			record.Result = events.KinesisFirehoseTransformedStateDropped
+		default:
+			data := []byte(result.Response.Body)
+
+			if result.Response.IsBase64Encoded {
+				b, err := base64.StdEncoding.DecodeString(result.Response.Body)
+				if err != nil {
+					break
+				}
+
+				data = b
+			}
+
+			record.Result = events.KinesisFirehoseTransformedStateOk
+			record.Data = data
+		}
+
+		res.Records = append(res.Records, record)
+	}
+
+	return res, nil
+}
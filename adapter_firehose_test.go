@@ -0,0 +1,65 @@
+package lamway
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/danteay/lamway/request"
+)
+
+func TestFirehoseAdapter(t *testing.T) {
+	evt := events.KinesisFirehoseEvent{
+		InvocationID: "inv-1",
+		Records: []events.KinesisFirehoseEventRecord{
+			{RecordID: "r-1", Data: []byte(`{"level":"info","msg":"hello"}`)},
+			{RecordID: "r-2", Data: []byte(`{"level":"debug","msg":"noise"}`)},
+			{RecordID: "r-3", Data: []byte(`not json`)},
+		},
+	}
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		var entry map[string]string
+
+		b, _ := io.ReadAll(r.Body)
+
+		assert.NotEmpty(t, r.Header.Get(request.HeaderRecordID))
+
+		if err := json.Unmarshal(b, &entry); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if entry["level"] == "debug" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		_, _ = w.Write([]byte(strings.ToUpper(entry["msg"]) + "\n"))
+	}
+
+	gw := New[events.KinesisFirehoseEvent](
+		WithHTTPHandler(http.HandlerFunc(handler)),
+		WithBatchAdapter[events.KinesisFirehoseEvent](FirehoseAdapter{Concurrency: 2}),
+	)
+
+	payload, err := gw.invoke(context.Background(), evt)
+
+	res, errMarshal := json.Marshal(payload)
+	if errMarshal != nil {
+		assert.Fail(t, "can't marshal payload", errMarshal)
+	}
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"records": [
+		{"recordId": "r-1", "result": "Ok", "data": "SEVMTE8K", "metadata": {"partitionKeys": null}},
+		{"recordId": "r-2", "result": "Dropped", "data": "eyJsZXZlbCI6ImRlYnVnIiwibXNnIjoibm9pc2UifQ==", "metadata": {"partitionKeys": null}},
+		{"recordId": "r-3", "result": "ProcessingFailed", "data": "bm90IGpzb24=", "metadata": {"partitionKeys": null}}
+	]}`, string(res))
+}
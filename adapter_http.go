@@ -0,0 +1,133 @@
+package lamway
+
+import (
+	"context"
+	"net/http"
+	"os"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/danteay/lamway/request"
+	"github.com/danteay/lamway/response"
+	"github.com/danteay/lamway/websocket"
+)
+
+// APIGatewayV1Adapter is the built-in adapter for API Gateway REST APIs.
+type APIGatewayV1Adapter struct{}
+
+// DecodeRequest implementation.
+func (APIGatewayV1Adapter) DecodeRequest(ctx context.Context, evt events.APIGatewayProxyRequest) (*http.Request, error) {
+	return request.NewV1(ctx, evt)
+}
+
+// EncodeResponse implementation.
+func (APIGatewayV1Adapter) EncodeResponse(_ context.Context, _ events.APIGatewayProxyRequest, res response.APIGatewayResponse) (any, error) {
+	return res.ToV1Map(), nil
+}
+
+// APIGatewayV2Adapter is the built-in adapter for API Gateway HTTP APIs.
+type APIGatewayV2Adapter struct{}
+
+// DecodeRequest implementation.
+func (APIGatewayV2Adapter) DecodeRequest(ctx context.Context, evt events.APIGatewayV2HTTPRequest) (*http.Request, error) {
+	return request.NewV2(ctx, evt)
+}
+
+// EncodeResponse implementation.
+func (APIGatewayV2Adapter) EncodeResponse(_ context.Context, _ events.APIGatewayV2HTTPRequest, res response.APIGatewayResponse) (any, error) {
+	return res.ToV2Map(), nil
+}
+
+// WebsocketAdapter is the built-in adapter for API Gateway WebSocket APIs. Every route is served as a POST to the
+// route key, and the ConnectionManager is added to the request context. When ConnectionManager is nil a
+// websocket.HTTPConnectionManager pointing to the API that sent the event is used.
+type WebsocketAdapter struct {
+	ConnectionManager websocket.ConnectionManager
+}
+
+// DecodeRequest implementation.
+func (a WebsocketAdapter) DecodeRequest(ctx context.Context, evt events.APIGatewayWebsocketProxyRequest) (*http.Request, error) {
+	connManager := a.ConnectionManager
+	if connManager == nil {
+		connManager = websocket.NewHTTPConnectionManager(websocketEndpoint(evt))
+	}
+
+	return request.NewWebsocket(websocket.NewContext(ctx, connManager), evt)
+}
+
+// EncodeResponse implementation.
+func (WebsocketAdapter) EncodeResponse(_ context.Context, _ events.APIGatewayWebsocketProxyRequest, res response.APIGatewayResponse) (any, error) {
+	return res.ToWebsocketMap(), nil
+}
+
+// websocketEndpoint returns the `@connections` endpoint of the API that sent the event. It's built from the API id, as
+// with custom domains the domain name and base path mapping of the event don't reach the management API. The domain
+// name is only used when the API id or the region aren't known, like in local emulators.
+func websocketEndpoint(evt events.APIGatewayWebsocketProxyRequest) string {
+	rc := evt.RequestContext
+	region := os.Getenv("AWS_REGION")
+
+	if rc.APIID == "" || region == "" {
+		return "https://" + rc.DomainName + "/" + rc.Stage
+	}
+
+	return "https://" + rc.APIID + ".execute-api." + region + ".amazonaws.com/" + rc.Stage
+}
+
+// FunctionURLAdapter is the built-in adapter for Lambda Function URLs.
+type FunctionURLAdapter struct{}
+
+// DecodeRequest implementation.
+func (FunctionURLAdapter) DecodeRequest(ctx context.Context, evt events.LambdaFunctionURLRequest) (*http.Request, error) {
+	return request.NewFunctionURL(ctx, evt)
+}
+
+// EncodeResponse implementation.
+func (FunctionURLAdapter) EncodeResponse(_ context.Context, _ events.LambdaFunctionURLRequest, res response.APIGatewayResponse) (any, error) {
+	return res.ToFunctionURLMap(), nil
+}
+
+// SupportsStreaming implementation.
+func (FunctionURLAdapter) SupportsStreaming(events.LambdaFunctionURLRequest) bool {
+	return true
+}
+
+// ALBAdapter is the built-in adapter for Application Load Balancer target groups.
+type ALBAdapter struct{}
+
+// DecodeRequest implementation.
+func (ALBAdapter) DecodeRequest(ctx context.Context, evt events.ALBTargetGroupRequest) (*http.Request, error) {
+	return request.NewALB(ctx, evt)
+}
+
+// EncodeResponse implementation. ALB sends only multiValueHeaders when the target group has multi-value headers
+// enabled, and expects the response to use the same format.
+func (ALBAdapter) EncodeResponse(_ context.Context, evt events.ALBTargetGroupRequest, res response.APIGatewayResponse) (any, error) {
+	return res.ToALBMap(len(evt.MultiValueHeaders) > 0), nil
+}
+
+// VPCLatticeV1Adapter is the built-in adapter for VPC Lattice target groups using the V1 event structure.
+type VPCLatticeV1Adapter struct{}
+
+// DecodeRequest implementation.
+func (VPCLatticeV1Adapter) DecodeRequest(ctx context.Context, evt request.VPCLatticeEventV1) (*http.Request, error) {
+	return request.NewVPCLatticeV1(ctx, evt)
+}
+
+// EncodeResponse implementation.
+func (VPCLatticeV1Adapter) EncodeResponse(_ context.Context, _ request.VPCLatticeEventV1, res response.APIGatewayResponse) (any, error) {
+	return res.ToVPCLatticeMap(), nil
+}
+
+// VPCLatticeV2Adapter is the built-in adapter for VPC Lattice target groups using the V2 event structure.
+type VPCLatticeV2Adapter struct{}
+
+// DecodeRequest implementation.
+func (VPCLatticeV2Adapter) DecodeRequest(ctx context.Context, evt request.VPCLatticeEventV2) (*http.Request, error) {
+	return request.NewVPCLatticeV2(ctx, evt)
+}
+
+// EncodeResponse implementation.
+func (VPCLatticeV2Adapter) EncodeResponse(_ context.Context, _ request.VPCLatticeEventV2, res response.APIGatewayResponse) (any, error) {
+	return res.ToVPCLatticeMap(), nil
+}
@@ -0,0 +1,58 @@
+package lamway
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/danteay/lamway/request"
+)
+
+// KafkaAdapter is the built-in adapter for Amazon MSK and self-managed Apache Kafka events. Every record is served as a
+// POST request to the path of its topic in Routes, or to Path, "/" by default, when there isn't one.
+//
+// Records are processed in order per partition, up to Concurrency partitions at the same time, and the processing of
+// a partition stops at its first non 2xx response. Kafka event sources don't support partial batch responses, so the
+// invocation fails with ErrHandlerFailed and the whole batch is retried.
+type KafkaAdapter struct {
+	Routes      map[string]string
+	Path        string
+	Concurrency int
+}
+
+// DecodeBatch implementation.
+func (a KafkaAdapter) DecodeBatch(ctx context.Context, evt events.KafkaEvent) (Batch, error) {
+	// records are grouped by <topic>-<partition>, sorted to process them in a stable order
+	partitions := make([]string, 0, len(evt.Records))
+	for partition := range evt.Records {
+		partitions = append(partitions, partition)
+	}
+
+	sort.Strings(partitions)
+
+	batch := Batch{Concurrency: a.Concurrency, StopOnFailure: true}
+
+	for _, partition := range partitions {
+		for _, record := range evt.Records[partition] {
+			req, err := request.NewKafka(ctx, record, streamPath(a.Routes, record.Topic, a.Path))
+			if err != nil {
+				return Batch{}, err
+			}
+
+			batch.Requests = append(batch.Requests, BatchRequest{
+				ID:      fmt.Sprintf("%s@%d", partition, record.Offset),
+				Group:   partition,
+				Request: req,
+			})
+		}
+	}
+
+	return batch, nil
+}
+
+// EncodeBatch implementation.
+func (KafkaAdapter) EncodeBatch(_ context.Context, _ events.KafkaEvent, results []BatchResult) (any, error) {
+	return nil, batchError(results)
+}
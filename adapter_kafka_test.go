@@ -0,0 +1,79 @@
+package lamway
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/danteay/lamway/request"
+)
+
+func TestKafkaAdapter(t *testing.T) {
+	record := func(topic string, partition, offset int64, value string) events.KafkaRecord {
+		return events.KafkaRecord{
+			Topic:     topic,
+			Partition: partition,
+			Offset:    offset,
+			Key:       base64.StdEncoding.EncodeToString([]byte("key-1")),
+			Value:     base64.StdEncoding.EncodeToString([]byte(value)),
+			Headers:   []map[string]events.JSONNumberBytes{{"Content-Type": []byte("application/json")}},
+		}
+	}
+
+	evt := events.KafkaEvent{
+		EventSource: "aws:kafka",
+		Records: map[string][]events.KafkaRecord{
+			"orders-0": {record("orders", 0, 10, `{"id":1}`), record("orders", 0, 11, `{"id":2}`), record("orders", 0, 12, `{"id":3}`)},
+			"orders-1": {record("orders", 1, 5, `{"id":4}`)},
+			"audit-0":  {record("audit", 0, 1, `{"action":"login"}`)},
+		},
+	}
+
+	var (
+		mu   sync.Mutex
+		seen = make(map[string][]string)
+	)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/consumers/orders", func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "key-1", r.Header.Get(request.HeaderRecordKey))
+
+		partition := r.Header.Get(request.HeaderPartition)
+
+		mu.Lock()
+		seen[partition] = append(seen[partition], string(b))
+		mu.Unlock()
+
+		if string(b) == `{"id":2}` {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "audit", r.Header.Get(request.HeaderTopic))
+	})
+
+	gw := New[events.KafkaEvent](
+		WithHTTPHandler(mux),
+		WithBatchAdapter[events.KafkaEvent](KafkaAdapter{
+			Routes:      map[string]string{"orders": "/consumers/orders"},
+			Concurrency: 3,
+		}),
+	)
+
+	_, err := gw.invoke(context.Background(), evt)
+
+	assert.ErrorIs(t, err, ErrHandlerFailed)
+	assert.EqualError(t, err, ErrHandlerFailed.Error()+": orders-0@11 (status 400), orders-0@12 (skipped)")
+	assert.Equal(t, map[string][]string{"0": {`{"id":1}`, `{"id":2}`}, "1": {`{"id":4}`}}, seen)
+}
@@ -0,0 +1,137 @@
+package lamway
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/danteay/lamway/request"
+)
+
+const defaultSNSPath = "/"
+
+// SNSAdapter is the built-in adapter for SNS events. Every record is served as a POST request to Path, "/" by
+// default, and the invocation fails with ErrHandlerFailed when any of them answers with a non 2xx status code, so SNS
+// retries the delivery.
+type SNSAdapter struct {
+	Path string
+}
+
+// DecodeBatch implementation.
+func (a SNSAdapter) DecodeBatch(ctx context.Context, evt events.SNSEvent) (Batch, error) {
+	batch := Batch{Requests: make([]BatchRequest, 0, len(evt.Records))}
+
+	for _, record := range evt.Records {
+		req, err := request.NewSNS(ctx, record, pathOrDefault(a.Path, defaultSNSPath))
+		if err != nil {
+			return Batch{}, err
+		}
+
+		batch.Requests = append(batch.Requests, BatchRequest{ID: record.SNS.MessageID, Request: req})
+	}
+
+	return batch, nil
+}
+
+// EncodeBatch implementation.
+func (SNSAdapter) EncodeBatch(_ context.Context, _ events.SNSEvent, results []BatchResult) (any, error) {
+	return nil, batchError(results)
+}
+
+// S3Route maps the S3 notifications matching all its non empty fields to Path. EventName matches the exact event
+// name, e.g. "ObjectCreated:Put", or all the events starting with it when it ends with "*", e.g. "ObjectCreated:*".
+type S3Route struct {
+	EventName string
+	Bucket    string
+	Prefix    string
+	Path      string
+}
+
+// S3Adapter is the built-in adapter for S3 event notifications. Every record is served as a POST request to the path
+// of the first matching route, with the record as the JSON body.
+//
+// Records without a matching route fail with ErrNoRoute, and the invocation fails with ErrHandlerFailed when any of
+// them answers with a non 2xx status code, so the notification is retried.
+type S3Adapter struct {
+	Routes []S3Route
+}
+
+// DecodeBatch implementation.
+func (a S3Adapter) DecodeBatch(ctx context.Context, evt events.S3Event) (Batch, error) {
+	batch := Batch{Requests: make([]BatchRequest, 0, len(evt.Records))}
+
+	for _, record := range evt.Records {
+		route, ok := a.route(record)
+		if !ok {
+			return Batch{}, fmt.Errorf("%w: %s s3://%s/%s", ErrNoRoute, record.EventName, record.S3.Bucket.Name, request.S3ObjectKey(record))
+		}
+
+		req, err := request.NewS3(ctx, record, route.Path)
+		if err != nil {
+			return Batch{}, err
+		}
+
+		batch.Requests = append(batch.Requests, BatchRequest{ID: record.S3.Object.Sequencer, Request: req})
+	}
+
+	return batch, nil
+}
+
+// EncodeBatch implementation.
+func (S3Adapter) EncodeBatch(_ context.Context, _ events.S3Event, results []BatchResult) (any, error) {
+	return nil, batchError(results)
+}
+
+func (a S3Adapter) route(record events.S3EventRecord) (S3Route, bool) {
+	key := request.S3ObjectKey(record)
+
+	for _, route := range a.Routes {
+		if route.EventName != "" && !matchEventName(route.EventName, record.EventName) {
+			continue
+		}
+
+		if route.Bucket != "" && route.Bucket != record.S3.Bucket.Name {
+			continue
+		}
+
+		if strings.HasPrefix(key, route.Prefix) {
+			return route, true
+		}
+	}
+
+	return S3Route{}, false
+}
+
+// matchEventName reports whether the name matches pattern. S3 event names are sent without the "s3:" prefix used in
+// the notification configuration, so it's ignored.
+func matchEventName(pattern, name string) bool {
+	pattern = strings.TrimPrefix(pattern, "s3:")
+
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(name, prefix)
+	}
+
+	return pattern == name
+}
+
+// batchError returns an ErrHandlerFailed error listing the failed requests, or nil when all of them succeeded.
+func batchError(results []BatchResult) error {
+	var failed []string
+
+	for _, result := range results {
+		switch {
+		case result.Skipped:
+			failed = append(failed, result.ID+" (skipped)")
+		case result.Failed():
+			failed = append(failed, fmt.Sprintf("%s (status %d)", result.ID, result.Response.StatusCode))
+		}
+	}
+
+	if len(failed) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s", ErrHandlerFailed, strings.Join(failed, ", "))
+}
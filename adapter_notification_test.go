@@ -0,0 +1,105 @@
+package lamway
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/danteay/lamway/request"
+)
+
+func TestSNSAdapter(t *testing.T) {
+	evt := events.SNSEvent{
+		Records: []events.SNSEventRecord{
+			{
+				SNS: events.SNSEntity{
+					MessageID: "m-1",
+					TopicArn:  "arn:aws:sns:us-east-1:123456789012:pets",
+					Subject:   "New pet",
+					Message:   `{"pet":"luna"}`,
+					MessageAttributes: map[string]any{
+						"Tenant": map[string]any{"Type": "String", "Value": "acme"},
+					},
+				},
+			},
+		},
+	}
+
+	status := http.StatusAccepted
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/notifications/pets", func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+
+		assert.Equal(t, "New pet", r.Header.Get(request.HeaderSubject))
+		assert.Equal(t, "acme", r.Header.Get("Tenant"))
+		assert.Equal(t, "m-1", r.Header.Get(request.HeaderMessageID))
+		assert.Equal(t, `{"pet":"luna"}`, string(b))
+
+		w.WriteHeader(status)
+	})
+
+	gw := New[events.SNSEvent](
+		WithHTTPHandler(mux),
+		WithBatchAdapter[events.SNSEvent](SNSAdapter{Path: "/notifications/pets"}),
+	)
+
+	_, err := gw.invoke(context.Background(), evt)
+
+	assert.NoError(t, err)
+
+	status = http.StatusInternalServerError
+
+	_, err = gw.invoke(context.Background(), evt)
+
+	assert.ErrorIs(t, err, ErrHandlerFailed)
+}
+
+func TestS3Adapter(t *testing.T) {
+	newEvent := func(eventName, bucket, key string) events.S3Event {
+		var evt events.S3Event
+
+		// unmarshal to get the decoded key set by the events package
+		payload := `{"Records":[{"eventName":"` + eventName + `","s3":{"bucket":{"name":"` + bucket + `"},"object":{"key":"` + key + `","sequencer":"01"}}}]}`
+		if err := json.Unmarshal([]byte(payload), &evt); err != nil {
+			t.Fatal(err)
+		}
+
+		return evt
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/uploads/avatars", func(w http.ResponseWriter, r *http.Request) {
+		var record events.S3EventRecord
+
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&record))
+		assert.Equal(t, "avatars/luna profile.png", r.Header.Get(request.HeaderObjectKey))
+		assert.Equal(t, "pets-uploads", record.S3.Bucket.Name)
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	gw := New[events.S3Event](
+		WithHTTPHandler(mux),
+		WithBatchAdapter[events.S3Event](S3Adapter{
+			Routes: []S3Route{
+				{EventName: "s3:ObjectCreated:*", Bucket: "pets-uploads", Prefix: "avatars/", Path: "/uploads/avatars"},
+			},
+		}),
+	)
+
+	_, err := gw.invoke(context.Background(), newEvent("ObjectCreated:Put", "pets-uploads", "avatars/luna+profile.png"))
+
+	assert.NoError(t, err)
+
+	_, err = gw.invoke(context.Background(), newEvent("ObjectRemoved:Delete", "pets-uploads", "avatars/luna.png"))
+
+	assert.ErrorIs(t, err, ErrNoRoute)
+}
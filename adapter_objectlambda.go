@@ -0,0 +1,122 @@
+package lamway
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/danteay/lamway/request"
+	"github.com/danteay/lamway/response"
+)
+
+// ObjectResponse is the transformed object of an S3 Object Lambda GetObject request.
+type ObjectResponse struct {
+	RequestRoute string
+	RequestToken string
+	StatusCode   int
+	Headers      map[string]string
+	Body         io.Reader
+}
+
+// ObjectResponseWriter sends the transformed object back to S3 Object Lambda, usually calling the
+// WriteGetObjectResponse API with the AWS SDK. The Body must be consumed before returning.
+type ObjectResponseWriter interface {
+	WriteGetObjectResponse(ctx context.Context, res ObjectResponse) error
+}
+
+// S3ObjectLambdaAdapter is the built-in adapter for S3 Object Lambda GetObject requests. The request is served as a GET
+// of the original object key, whose content can be fetched from request.ObjectLambdaInputURL, and the handler output
+// is streamed to the Writer as it's written.
+type S3ObjectLambdaAdapter struct {
+	Writer ObjectResponseWriter
+}
+
+// DecodeRequest implementation.
+func (S3ObjectLambdaAdapter) DecodeRequest(ctx context.Context, evt events.S3ObjectLambdaEvent) (*http.Request, error) {
+	return request.NewS3ObjectLambda(ctx, evt)
+}
+
+// EncodeResponse implementation. It's only called with the default error response when the request can't be built.
+func (a S3ObjectLambdaAdapter) EncodeResponse(ctx context.Context, evt events.S3ObjectLambdaEvent, res response.APIGatewayResponse) (any, error) {
+	if evt.GetObjectContext == nil {
+		return nil, nil
+	}
+
+	return nil, a.write(ctx, evt, ObjectResponse{
+		StatusCode: res.StatusCode,
+		Headers:    res.Headers,
+		Body:       strings.NewReader(res.Body),
+	})
+}
+
+// EncodeStream implementation.
+func (a S3ObjectLambdaAdapter) EncodeStream(ctx context.Context, evt events.S3ObjectLambdaEvent, res *events.LambdaFunctionURLStreamingResponse) (any, error) {
+	err := a.write(ctx, evt, ObjectResponse{
+		StatusCode: res.StatusCode,
+		Headers:    res.Headers,
+		Body:       res.Body,
+	})
+
+	// unblock the handler if the writer didn't read the whole body
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	return nil, err
+}
+
+func (a S3ObjectLambdaAdapter) write(ctx context.Context, evt events.S3ObjectLambdaEvent, res ObjectResponse) error {
+	if a.Writer == nil {
+		return ErrMissingObjectResponseWriter
+	}
+
+	res.RequestRoute = evt.GetObjectContext.OutputRoute
+	res.RequestToken = evt.GetObjectContext.OutputToken
+
+	return a.Writer.WriteGetObjectResponse(ctx, res)
+}
+
+// WrittenObject is an ObjectResponse recorded by MemoryObjectResponseWriter, with the whole body read into Data.
+type WrittenObject struct {
+	ObjectResponse
+	Data []byte
+}
+
+// MemoryObjectResponseWriter is an in-memory ObjectResponseWriter to test S3 Object Lambda handlers.
+type MemoryObjectResponseWriter struct {
+	mu      sync.Mutex
+	objects []WrittenObject
+}
+
+// NewMemoryObjectResponseWriter creates an empty MemoryObjectResponseWriter.
+func NewMemoryObjectResponseWriter() *MemoryObjectResponseWriter {
+	return &MemoryObjectResponseWriter{}
+}
+
+// WriteGetObjectResponse implementation.
+func (m *MemoryObjectResponseWriter) WriteGetObjectResponse(_ context.Context, res ObjectResponse) error {
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.objects = append(m.objects, WrittenObject{ObjectResponse: res, Data: data})
+
+	return nil
+}
+
+// Objects returns the responses written so far.
+func (m *MemoryObjectResponseWriter) Objects() []WrittenObject {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	out := make([]WrittenObject, len(m.objects))
+	copy(out, m.objects)
+
+	return out
+}
@@ -0,0 +1,90 @@
+package lamway
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/danteay/lamway/request"
+)
+
+func TestS3ObjectLambdaAdapter(t *testing.T) {
+	origin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("name=luna\nssn=123-45-6789\n"))
+	}))
+	defer origin.Close()
+
+	// redacts the ssn lines of the original object
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/pets/luna.txt", r.URL.Path)
+
+		inputURL, ok := request.ObjectLambdaInputURL(r.Context())
+		assert.True(t, ok)
+
+		res, err := http.Get(inputURL)
+		if err != nil {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		defer func() {
+			_ = res.Body.Close()
+		}()
+
+		body, _ := io.ReadAll(res.Body)
+
+		w.Header().Set("Content-Type", "text/plain")
+
+		for _, line := range bytes.SplitAfter(body, []byte("\n")) {
+			if !bytes.HasPrefix(line, []byte("ssn=")) {
+				_, _ = w.Write(line)
+			}
+		}
+	}
+
+	evt := events.S3ObjectLambdaEvent{
+		XAmzRequestID: "req-1",
+		GetObjectContext: &events.S3ObjectLambdaGetObjectContext{
+			InputS3URL:  origin.URL + "/pets/luna.txt?X-Amz-Signature=abc",
+			OutputRoute: "io-route",
+			OutputToken: "io-token",
+		},
+		UserRequest: events.S3ObjectLambdaUserRequest{
+			URL: "https://pets-olap-123456789012.s3-object-lambda.us-east-1.amazonaws.com/pets/luna.txt",
+		},
+	}
+
+	writer := NewMemoryObjectResponseWriter()
+
+	gw := New[events.S3ObjectLambdaEvent](
+		WithHTTPHandler(http.HandlerFunc(handler)),
+		WithAdapter[events.S3ObjectLambdaEvent](S3ObjectLambdaAdapter{Writer: writer}),
+	)
+
+	_, err := gw.invoke(context.Background(), evt)
+
+	objects := writer.Objects()
+
+	assert.NoError(t, err)
+	assert.Len(t, objects, 1)
+	assert.Equal(t, "io-route", objects[0].RequestRoute)
+	assert.Equal(t, "io-token", objects[0].RequestToken)
+	assert.Equal(t, http.StatusOK, objects[0].StatusCode)
+	assert.Equal(t, "text/plain", objects[0].Headers["Content-Type"])
+	assert.Equal(t, "name=luna\n", string(objects[0].Data))
+
+	t.Run("missing writer", func(t *testing.T) {
+		gw := New[events.S3ObjectLambdaEvent](WithHTTPHandler(http.HandlerFunc(handler)))
+
+		_, err := gw.invoke(context.Background(), evt)
+
+		assert.ErrorIs(t, err, ErrMissingObjectResponseWriter)
+	})
+}
@@ -0,0 +1,59 @@
+package lamway
+
+import (
+	"context"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/danteay/lamway/request"
+)
+
+const defaultSQSPath = "/"
+
+// SQSAdapter is the built-in adapter for SQS events. Every message is served as a POST request to Path, "/" by
+// default, and the failed messages are reported in `batchItemFailures`.
+//
+// Up to Concurrency messages are processed at the same time. Messages of FIFO queues are processed in order inside
+// their message group, and once one of them fails the rest of its group is reported as failed without being
+// processed, so they are retried in order.
+type SQSAdapter struct {
+	Path        string
+	Concurrency int
+}
+
+// DecodeBatch implementation.
+func (a SQSAdapter) DecodeBatch(ctx context.Context, evt events.SQSEvent) (Batch, error) {
+	batch := Batch{
+		Requests:      make([]BatchRequest, 0, len(evt.Records)),
+		Concurrency:   a.Concurrency,
+		StopOnFailure: true,
+	}
+
+	for _, msg := range evt.Records {
+		req, err := request.NewSQS(ctx, msg, pathOrDefault(a.Path, defaultSQSPath))
+		if err != nil {
+			return Batch{}, err
+		}
+
+		batch.Requests = append(batch.Requests, BatchRequest{
+			ID:      msg.MessageId,
+			Group:   msg.Attributes["MessageGroupId"],
+			Request: req,
+		})
+	}
+
+	return batch, nil
+}
+
+// EncodeBatch implementation.
+func (SQSAdapter) EncodeBatch(_ context.Context, _ events.SQSEvent, results []BatchResult) (any, error) {
+	res := events.SQSEventResponse{BatchItemFailures: []events.SQSBatchItemFailure{}}
+
+	for _, result := range results {
+		if result.Failed() {
+			res.BatchItemFailures = append(res.BatchItemFailures, events.SQSBatchItemFailure{ItemIdentifier: result.ID})
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,144 @@
+package lamway
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/danteay/lamway/request"
+)
+
+func TestSQSAdapter(t *testing.T) {
+	str := func(s string) *string { return &s }
+
+	t.Run("standard queue", func(t *testing.T) {
+		evt := events.SQSEvent{
+			Records: []events.SQSMessage{
+				{
+					MessageId:      "m-1",
+					Body:           `{"pet":"luna"}`,
+					EventSourceARN: "arn:aws:sqs:us-east-1:123456789012:pets",
+					MessageAttributes: map[string]events.SQSMessageAttribute{
+						"Content-Type": {StringValue: str("application/json"), DataType: "String"},
+						"Tenant":       {StringValue: str("acme"), DataType: "String"},
+					},
+				},
+				{MessageId: "m-2", Body: `{"pet":"loki"}`},
+			},
+		}
+
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			b, _ := io.ReadAll(r.Body)
+
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/events/pets", r.URL.Path)
+
+			msg, ok := r.Context().Value(request.ContextKey).(events.SQSMessage)
+			assert.True(t, ok)
+			assert.Equal(t, msg.MessageId, r.Header.Get(request.HeaderMessageID))
+
+			if msg.MessageId == "m-1" {
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				assert.Equal(t, "acme", r.Header.Get("Tenant"))
+				assert.Equal(t, "arn:aws:sqs:us-east-1:123456789012:pets", r.Header.Get(request.HeaderSourceARN))
+				assert.Equal(t, `{"pet":"luna"}`, string(b))
+
+				w.WriteHeader(http.StatusAccepted)
+
+				return
+			}
+
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		}
+
+		gw := New[events.SQSEvent](
+			WithHTTPHandler(http.HandlerFunc(handler)),
+			WithBatchAdapter[events.SQSEvent](SQSAdapter{Path: "/events/pets", Concurrency: 2}),
+		)
+
+		res, err := gw.invoke(context.Background(), evt)
+
+		assert.NoError(t, err)
+		assert.Equal(t, events.SQSEventResponse{
+			BatchItemFailures: []events.SQSBatchItemFailure{{ItemIdentifier: "m-2"}},
+		}, res)
+	})
+
+	t.Run("fifo queue", func(t *testing.T) {
+		fifo := func(id, group string) events.SQSMessage {
+			return events.SQSMessage{MessageId: id, Attributes: map[string]string{"MessageGroupId": group}}
+		}
+
+		evt := events.SQSEvent{
+			Records: []events.SQSMessage{
+				fifo("a-1", "a"), fifo("b-1", "b"), fifo("a-2", "a"), fifo("a-3", "a"), fifo("b-2", "b"),
+			},
+		}
+
+		var (
+			mu   sync.Mutex
+			seen = make(map[string][]string)
+		)
+
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			msg, _ := r.Context().Value(request.ContextKey).(events.SQSMessage)
+
+			mu.Lock()
+			group := msg.Attributes["MessageGroupId"]
+			seen[group] = append(seen[group], msg.MessageId)
+			mu.Unlock()
+
+			if msg.MessageId == "a-2" {
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+		}
+
+		gw := New[events.SQSEvent](
+			WithHTTPHandler(http.HandlerFunc(handler)),
+			WithBatchAdapter[events.SQSEvent](SQSAdapter{Concurrency: 2}),
+		)
+
+		res, err := gw.invoke(context.Background(), evt)
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string][]string{"a": {"a-1", "a-2"}, "b": {"b-1", "b-2"}}, seen)
+		assert.Equal(t, events.SQSEventResponse{
+			BatchItemFailures: []events.SQSBatchItemFailure{{ItemIdentifier: "a-2"}, {ItemIdentifier: "a-3"}},
+		}, res)
+	})
+}
+
+func TestSQSAdapter_panic(t *testing.T) {
+	evt := events.SQSEvent{
+		Records: []events.SQSMessage{
+			{MessageId: "m-1", Body: "luna"},
+			{MessageId: "m-2", Body: "panic"},
+			{MessageId: "m-3", Body: "loki"},
+		},
+	}
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if b, _ := io.ReadAll(r.Body); string(b) == "panic" {
+			panic("boom")
+		}
+	}
+
+	for _, concurrency := range []int{0, 4} {
+		gw := New[events.SQSEvent](
+			WithHTTPHandler(http.HandlerFunc(handler)),
+			WithBatchAdapter[events.SQSEvent](SQSAdapter{Concurrency: concurrency}),
+		)
+
+		res, err := gw.invoke(context.Background(), evt)
+
+		assert.NoError(t, err)
+		assert.Equal(t, events.SQSEventResponse{
+			BatchItemFailures: []events.SQSBatchItemFailure{{ItemIdentifier: "m-2"}},
+		}, res)
+	}
+}
@@ -0,0 +1,111 @@
+package lamway
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/lambda/messages"
+
+	"github.com/danteay/lamway/request"
+	"github.com/danteay/lamway/response"
+)
+
+const (
+	defaultStepFunctionsPath       = "/"
+	defaultStepFunctionsPathField  = "path"
+	defaultStepFunctionsTokenField = "taskToken"
+)
+
+// HeaderErrorName is the response header used by Step Functions task handlers to choose the name of the error
+// returned for a non 2xx response.
+const HeaderErrorName = "X-Error-Name"
+
+// StepFunctionsAdapter is the built-in adapter for Step Functions Lambda tasks. The task input is served as a POST
+// request with the input as the JSON body, to the path in the PathField of the input, "path" by default, or to Path,
+// "/" by default, when there isn't one. The JSON body of the handler response is returned as the task output.
+//
+// Non 2xx responses fail the task with an error named after the X-Error-Name response header, or the status text
+// without spaces, e.g. "NotFound", that can be caught by name in the state machine, with the body as the cause.
+//
+// When WaitForTaskToken is true the task token is read from the TokenField of the input, "taskToken" by default, and
+// exposed through request.TaskToken.
+type StepFunctionsAdapter struct {
+	Path             string
+	PathField        string
+	WaitForTaskToken bool
+	TokenField       string
+}
+
+// DecodeRequest implementation.
+func (a StepFunctionsAdapter) DecodeRequest(ctx context.Context, evt request.StepFunctionsTask) (*http.Request, error) {
+	path := pathOrDefault(a.Path, defaultStepFunctionsPath)
+
+	if field := stringField(evt, pathOrDefault(a.PathField, defaultStepFunctionsPathField)); field != "" {
+		path = field
+	}
+
+	token := ""
+
+	if a.WaitForTaskToken {
+		token = stringField(evt, pathOrDefault(a.TokenField, defaultStepFunctionsTokenField))
+	}
+
+	return request.NewStepFunctions(ctx, evt, path, token)
+}
+
+// EncodeResponse implementation.
+func (StepFunctionsAdapter) EncodeResponse(_ context.Context, _ request.StepFunctionsTask, res response.APIGatewayResponse) (any, error) {
+	body := []byte(res.Body)
+
+	if res.IsBase64Encoded {
+		if b, err := base64.StdEncoding.DecodeString(res.Body); err == nil {
+			body = b
+		}
+	}
+
+	if failed(res) {
+		return nil, messages.InvokeResponse_Error{
+			Type:    stepFunctionsErrorName(res),
+			Message: strings.TrimSpace(string(body)),
+		}
+	}
+
+	if len(body) == 0 {
+		return nil, nil
+	}
+
+	if !json.Valid(body) {
+		return string(body), nil
+	}
+
+	return json.RawMessage(body), nil
+}
+
+func stepFunctionsErrorName(res response.APIGatewayResponse) string {
+	for k, v := range res.Headers {
+		if strings.EqualFold(k, HeaderErrorName) && v != "" {
+			return v
+		}
+	}
+
+	if text := http.StatusText(res.StatusCode); text != "" {
+		return strings.NewReplacer(" ", "", "-", "", "'", "").Replace(text)
+	}
+
+	return "HTTP" + strconv.Itoa(res.StatusCode)
+}
+
+// stringField returns the string value of a top level field of the task input.
+func stringField(evt request.StepFunctionsTask, name string) string {
+	var v string
+
+	if err := json.Unmarshal(evt.Field(name), &v); err != nil {
+		return ""
+	}
+
+	return v
+}
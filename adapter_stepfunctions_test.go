@@ -0,0 +1,75 @@
+package lamway
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/lambda/messages"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/danteay/lamway/request"
+)
+
+func TestStepFunctionsAdapter(t *testing.T) {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/jobs/resize", func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+
+		assert.JSONEq(t, `{"path":"/jobs/resize","taskToken":"token-1","image":"luna.png"}`, string(b))
+
+		token, ok := request.TaskToken(r.Context())
+		assert.True(t, ok)
+		assert.Equal(t, "token-1", token)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"thumbnail":"luna-small.png"}`))
+	})
+
+	mux.HandleFunc("/jobs/missing", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"image":"loki.png"}`))
+	})
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		_, ok := request.TaskToken(r.Context())
+		assert.False(t, ok)
+
+		w.Header().Set(HeaderErrorName, "Pets.InvalidInput")
+		w.WriteHeader(http.StatusBadRequest)
+	})
+
+	gw := New[request.StepFunctionsTask](
+		WithHTTPHandler(mux),
+		WithAdapter[request.StepFunctionsTask](StepFunctionsAdapter{WaitForTaskToken: true}),
+	)
+
+	t.Run("output", func(t *testing.T) {
+		res, err := gw.invoke(context.Background(), request.StepFunctionsTask(`{"path":"/jobs/resize","taskToken":"token-1","image":"luna.png"}`))
+
+		assert.NoError(t, err)
+		assert.Equal(t, json.RawMessage(`{"thumbnail":"luna-small.png"}`), res)
+	})
+
+	t.Run("status error", func(t *testing.T) {
+		_, err := gw.invoke(context.Background(), request.StepFunctionsTask(`{"path":"/jobs/missing"}`))
+
+		assert.Equal(t, messages.InvokeResponse_Error{Type: "NotFound", Message: `{"image":"loki.png"}`}, err)
+	})
+
+	t.Run("named error", func(t *testing.T) {
+		_, err := gw.invoke(context.Background(), request.StepFunctionsTask(`["not", "an", "object"]`))
+
+		assert.Equal(t, messages.InvokeResponse_Error{Type: "Pets.InvalidInput"}, err)
+	})
+
+	t.Run("unmarshal", func(t *testing.T) {
+		var task request.StepFunctionsTask
+
+		assert.NoError(t, json.Unmarshal([]byte(`{"path":"/jobs/resize"}`), &task))
+		assert.Equal(t, json.RawMessage(`"/jobs/resize"`), task.Field("path"))
+	})
+}
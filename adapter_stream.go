@@ -0,0 +1,111 @@
+package lamway
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/danteay/lamway/request"
+)
+
+const defaultStreamPath = "/"
+
+// KinesisAdapter is the built-in adapter for Kinesis Data Streams events, serving the records of each shard in order
+// as POST requests to the path of their partition key in Routes, or to Path, "/" by default.
+type KinesisAdapter struct {
+	Routes map[string]string
+	Path   string
+}
+
+// DecodeBatch implementation.
+func (a KinesisAdapter) DecodeBatch(ctx context.Context, evt events.KinesisEvent) (Batch, error) {
+	batch := Batch{
+		Requests:      make([]BatchRequest, 0, len(evt.Records)),
+		StopOnFailure: true,
+	}
+
+	for _, record := range evt.Records {
+		req, err := request.NewKinesis(ctx, record, streamPath(a.Routes, record.Kinesis.PartitionKey, a.Path))
+		if err != nil {
+			return Batch{}, err
+		}
+
+		// event IDs are <shard-id>:<sequence-number>
+		shard, _, _ := strings.Cut(record.EventID, ":")
+
+		batch.Requests = append(batch.Requests, BatchRequest{
+			ID:      record.Kinesis.SequenceNumber,
+			Group:   record.EventSourceArn + "/" + shard,
+			Request: req,
+		})
+	}
+
+	return batch, nil
+}
+
+// EncodeBatch implementation.
+func (KinesisAdapter) EncodeBatch(_ context.Context, _ events.KinesisEvent, results []BatchResult) (any, error) {
+	res := events.KinesisEventResponse{BatchItemFailures: []events.KinesisBatchItemFailure{}}
+
+	for _, result := range results {
+		if result.Failed() {
+			res.BatchItemFailures = append(res.BatchItemFailures, events.KinesisBatchItemFailure{ItemIdentifier: result.ID})
+		}
+	}
+
+	return res, nil
+}
+
+// DynamoDBAdapter is the built-in adapter for DynamoDB Streams events, serving the records in order as POST requests
+// to the path of their event name, INSERT, MODIFY or REMOVE, in Routes, or to Path, "/" by default.
+type DynamoDBAdapter struct {
+	Routes map[string]string
+	Path   string
+}
+
+// DecodeBatch implementation.
+func (a DynamoDBAdapter) DecodeBatch(ctx context.Context, evt events.DynamoDBEvent) (Batch, error) {
+	batch := Batch{
+		Requests:      make([]BatchRequest, 0, len(evt.Records)),
+		StopOnFailure: true,
+	}
+
+	for _, record := range evt.Records {
+		req, err := request.NewDynamoDB(ctx, record, streamPath(a.Routes, record.EventName, a.Path))
+		if err != nil {
+			return Batch{}, err
+		}
+
+		// the records of an invocation come from a single shard of the stream
+		batch.Requests = append(batch.Requests, BatchRequest{
+			ID:      record.Change.SequenceNumber,
+			Group:   record.EventSourceArn,
+			Request: req,
+		})
+	}
+
+	return batch, nil
+}
+
+// EncodeBatch implementation.
+func (DynamoDBAdapter) EncodeBatch(_ context.Context, _ events.DynamoDBEvent, results []BatchResult) (any, error) {
+	res := events.DynamoDBEventResponse{BatchItemFailures: []events.DynamoDBBatchItemFailure{}}
+
+	for _, result := range results {
+		if result.Failed() {
+			res.BatchItemFailures = append(res.BatchItemFailures, events.DynamoDBBatchItemFailure{ItemIdentifier: result.ID})
+		}
+	}
+
+	return res, nil
+}
+
+// streamPath returns the route of key, or the fallback path when there isn't one.
+func streamPath(routes map[string]string, key, fallback string) string {
+	if path, ok := routes[key]; ok {
+		return path
+	}
+
+	return pathOrDefault(fallback, defaultStreamPath)
+}
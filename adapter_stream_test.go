@@ -0,0 +1,116 @@
+package lamway
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/danteay/lamway/request"
+)
+
+func TestKinesisAdapter(t *testing.T) {
+	record := func(seq, key, data string) events.KinesisEventRecord {
+		return events.KinesisEventRecord{
+			EventID:        "shardId-000000000000:" + seq,
+			EventSourceArn: "arn:aws:kinesis:us-east-1:123456789012:stream/pets",
+			Kinesis:        events.KinesisRecord{SequenceNumber: seq, PartitionKey: key, Data: []byte(data)},
+		}
+	}
+
+	evt := events.KinesisEvent{
+		Records: []events.KinesisEventRecord{
+			record("1", "visits", `{"pet":"luna"}`),
+			record("2", "adoptions", `{"pet":"loki"}`),
+			record("3", "visits", `{"pet":"tobi"}`),
+		},
+	}
+
+	var seen []string
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/visits", func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+
+		seen = append(seen, r.Header.Get(request.HeaderSequenceNumber))
+
+		assert.Equal(t, "visits", r.Header.Get(request.HeaderPartitionKey))
+		assert.Equal(t, `{"pet":"luna"}`, string(b))
+	})
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Header.Get(request.HeaderSequenceNumber))
+
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	gw := New[events.KinesisEvent](
+		WithHTTPHandler(mux),
+		WithBatchAdapter[events.KinesisEvent](KinesisAdapter{Routes: map[string]string{"visits": "/visits"}}),
+	)
+
+	res, err := gw.invoke(context.Background(), evt)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, seen)
+	assert.Equal(t, events.KinesisEventResponse{
+		BatchItemFailures: []events.KinesisBatchItemFailure{{ItemIdentifier: "2"}, {ItemIdentifier: "3"}},
+	}, res)
+}
+
+func TestDynamoDBAdapter(t *testing.T) {
+	evt := events.DynamoDBEvent{
+		Records: []events.DynamoDBEventRecord{
+			{
+				EventID:        "e-1",
+				EventName:      "INSERT",
+				EventSourceArn: "arn:aws:dynamodb:us-east-1:123456789012:table/pets/stream/2024",
+				Change: events.DynamoDBStreamRecord{
+					SequenceNumber: "100",
+					Keys:           map[string]events.DynamoDBAttributeValue{"id": events.NewStringAttribute("luna")},
+					NewImage: map[string]events.DynamoDBAttributeValue{
+						"id":   events.NewStringAttribute("luna"),
+						"age":  events.NewNumberAttribute("3"),
+						"tags": events.NewStringSetAttribute([]string{"cat"}),
+					},
+				},
+			},
+			{EventID: "e-2", EventName: "REMOVE", Change: events.DynamoDBStreamRecord{SequenceNumber: "200"}},
+		},
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/pets/inserted", func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+
+		assert.Equal(t, "INSERT", r.Header.Get(request.HeaderEventName))
+		assert.JSONEq(t, `{
+			"keys": {"id": "luna"},
+			"newImage": {"id": "luna", "age": 3, "tags": ["cat"]},
+			"oldImage": null
+		}`, string(b))
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.HandleFunc("/pets/removed", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	gw := New[events.DynamoDBEvent](
+		WithHTTPHandler(mux),
+		WithBatchAdapter[events.DynamoDBEvent](DynamoDBAdapter{
+			Routes: map[string]string{"INSERT": "/pets/inserted", "REMOVE": "/pets/removed"},
+		}),
+	)
+
+	res, err := gw.invoke(context.Background(), evt)
+
+	assert.NoError(t, err)
+	assert.Equal(t, events.DynamoDBEventResponse{BatchItemFailures: []events.DynamoDBBatchItemFailure{}}, res)
+}
@@ -0,0 +1,90 @@
+package lamway
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/danteay/lamway/response"
+)
+
+// envelopeAdapter handles an in-house `{"op": "...", "resource": "..."}` envelope.
+type envelopeAdapter struct{}
+
+func (envelopeAdapter) DecodeRequest(ctx context.Context, evt json.RawMessage) (*http.Request, error) {
+	var envelope struct {
+		Op       string `json:"op"`
+		Resource string `json:"resource"`
+	}
+
+	if err := json.Unmarshal(evt, &envelope); err != nil {
+		return nil, err
+	}
+
+	return http.NewRequestWithContext(ctx, envelope.Op, envelope.Resource, http.NoBody)
+}
+
+func (envelopeAdapter) EncodeResponse(_ context.Context, _ json.RawMessage, res response.APIGatewayResponse) (any, error) {
+	return map[string]any{"status": res.StatusCode, "result": res.Body}, nil
+}
+
+func TestGateway_WithAdapter(t *testing.T) {
+	t.Run("should use custom adapter", func(t *testing.T) {
+		gw := New[json.RawMessage](
+			WithHTTPHandler(http.HandlerFunc(hello)),
+			WithAdapter[json.RawMessage](envelopeAdapter{}),
+		)
+
+		payload, err := gw.invoke(context.Background(), json.RawMessage(`{"op":"GET","resource":"/pets/luna"}`))
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{"status": http.StatusOK, "result": "Hello World from Go\n"}, payload)
+	})
+
+	t.Run("should encode default response on decode error", func(t *testing.T) {
+		gw := New[json.RawMessage](
+			WithHTTPHandler(http.HandlerFunc(hello)),
+			WithAdapter[json.RawMessage](envelopeAdapter{}),
+		)
+
+		payload, err := gw.invoke(context.Background(), json.RawMessage(`[`))
+
+		assert.Error(t, err)
+		assert.Equal(t, map[string]any{"status": http.StatusInternalServerError, "result": `{"message": "Error processing request"}`}, payload)
+	})
+
+	t.Run("should fail on adapter mismatch", func(t *testing.T) {
+		gw := New[events.APIGatewayProxyRequest](WithAdapter[json.RawMessage](envelopeAdapter{}))
+
+		_, err := gw.invoke(context.Background(), events.APIGatewayProxyRequest{Path: testPath})
+
+		assert.ErrorIs(t, err, ErrAdapterMismatch)
+		assert.ErrorIs(t, gw.Start(), ErrAdapterMismatch)
+	})
+}
+
+func TestWebsocketEndpoint(t *testing.T) {
+	evt := events.APIGatewayWebsocketProxyRequest{
+		RequestContext: events.APIGatewayWebsocketProxyRequestContext{
+			APIID:      "abc123",
+			DomainName: "ws.example.com",
+			Stage:      "prod",
+		},
+	}
+
+	t.Run("custom domain", func(t *testing.T) {
+		t.Setenv("AWS_REGION", "eu-west-1")
+
+		assert.Equal(t, "https://abc123.execute-api.eu-west-1.amazonaws.com/prod", websocketEndpoint(evt))
+	})
+
+	t.Run("unknown region", func(t *testing.T) {
+		t.Setenv("AWS_REGION", "")
+
+		assert.Equal(t, "https://ws.example.com/prod", websocketEndpoint(evt))
+	})
+}
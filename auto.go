@@ -0,0 +1,191 @@
+package lamway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/danteay/lamway/request"
+	"github.com/danteay/lamway/response"
+	"github.com/danteay/lamway/websocket"
+)
+
+// eventProbe holds the fields used to tell apart the HTTP event sources.
+type eventProbe struct {
+	Version        string                `json:"version"`
+	HTTPMethod     string                `json:"httpMethod"`
+	RawPath        string                `json:"raw_path"`
+	Method         string                `json:"method"`
+	Path           string                `json:"path"`
+	RequestContext *eventProbeRequestCtx `json:"requestContext"`
+}
+
+// eventProbeRequestCtx holds the requestContext fields used to tell apart the HTTP event sources.
+type eventProbeRequestCtx struct {
+	ELB          json.RawMessage `json:"elb"`
+	HTTP         json.RawMessage `json:"http"`
+	ConnectionID string          `json:"connectionId"`
+	DomainName   string          `json:"domainName"`
+}
+
+// boundEvent is a detected event bound to the built-in adapter of its source.
+type boundEvent struct {
+	evt    any
+	decode func(ctx context.Context) (*http.Request, error)
+	encode func(ctx context.Context, res response.APIGatewayResponse) (any, error)
+}
+
+// eventSource is an event source told apart by AutoAdapter.
+type eventSource int
+
+const (
+	sourceUnknown eventSource = iota
+	sourceALB
+	sourceWebsocket
+	sourceFunctionURL
+	sourceAPIGatewayV2
+	sourceVPCLatticeV2
+	sourceVPCLatticeV1
+	sourceDirectInvoke
+	sourceAPIGatewayV1
+)
+
+// autoCtxKey is the type used for the items added by AutoAdapter to the request context.
+type autoCtxKey string
+
+// boundEventKey is the key for the boundEvent detected by DecodeRequest, so EncodeResponse doesn't decode the payload
+// again.
+const boundEventKey autoCtxKey = "gateway:autoBoundEvent"
+
+// NewAuto creates a gateway that receives the raw event payload and detects its source, so the same function can
+// serve API Gateway v1, v2 and WebSocket APIs, Function URLs, ALB and VPC Lattice traffic, and direct invocations
+// using the request.DirectInvokeEvent envelope. The response is encoded in the format expected by the detected source.
+func NewAuto(opts ...Option) *Gateway[json.RawMessage] {
+	return New[json.RawMessage](opts...)
+}
+
+// AutoAdapter is the built-in adapter for json.RawMessage events. It sniffs every payload and delegates to the
+// built-in adapter of the detected source.
+type AutoAdapter struct {
+	ConnectionManager websocket.ConnectionManager
+}
+
+// DecodeRequest implementation.
+func (a AutoAdapter) DecodeRequest(ctx context.Context, evt json.RawMessage) (*http.Request, error) {
+	b, err := a.detect(evt)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.decode(context.WithValue(ctx, boundEventKey, b))
+}
+
+// EncodeResponse implementation. Payloads from unknown sources are answered with the API Gateway v1 format.
+func (a AutoAdapter) EncodeResponse(ctx context.Context, evt json.RawMessage, res response.APIGatewayResponse) (any, error) {
+	b, ok := ctx.Value(boundEventKey).(boundEvent)
+	if !ok {
+		var err error
+
+		if b, err = a.detect(evt); err != nil {
+			return res.ToV1Map(), nil
+		}
+	}
+
+	return b.encode(ctx, res)
+}
+
+// SupportsStreaming implementation. Only the source is sniffed, as Function URLs are the only one that streams.
+func (a AutoAdapter) SupportsStreaming(evt json.RawMessage) bool {
+	src, err := sniff(evt)
+	return err == nil && src == sourceFunctionURL
+}
+
+// detect sniffs the raw payload and decodes it into the event type of its source.
+func (a AutoAdapter) detect(raw json.RawMessage) (boundEvent, error) {
+	src, err := sniff(raw)
+	if err != nil {
+		return boundEvent{}, err
+	}
+
+	switch src {
+	case sourceALB:
+		return bindEvent[events.ALBTargetGroupRequest](raw, ALBAdapter{})
+	case sourceWebsocket:
+		return bindEvent[events.APIGatewayWebsocketProxyRequest](raw, WebsocketAdapter{ConnectionManager: a.ConnectionManager})
+	case sourceFunctionURL:
+		return bindEvent[events.LambdaFunctionURLRequest](raw, FunctionURLAdapter{})
+	case sourceAPIGatewayV2:
+		return bindEvent[events.APIGatewayV2HTTPRequest](raw, APIGatewayV2Adapter{})
+	case sourceVPCLatticeV2:
+		return bindEvent[request.VPCLatticeEventV2](raw, VPCLatticeV2Adapter{})
+	case sourceVPCLatticeV1:
+		return bindEvent[request.VPCLatticeEventV1](raw, VPCLatticeV1Adapter{})
+	case sourceDirectInvoke:
+		return bindEvent[request.DirectInvokeEvent](raw, DirectInvokeAdapter{})
+	case sourceAPIGatewayV1:
+		return bindEvent[events.APIGatewayProxyRequest](raw, APIGatewayV1Adapter{})
+	default:
+		return boundEvent{}, ErrUnknownEventSource
+	}
+}
+
+// sniff tells the source of the raw payload apart from a few of its fields, without decoding the whole event.
+func sniff(raw json.RawMessage) (eventSource, error) {
+	var probe eventProbe
+
+	if err := json.Unmarshal(raw, &probe); err != nil {
+		return sourceUnknown, errors.Join(err, ErrUnknownEventSource)
+	}
+
+	var reqCtx eventProbeRequestCtx
+	if probe.RequestContext != nil {
+		reqCtx = *probe.RequestContext
+	}
+
+	switch {
+	case len(reqCtx.ELB) > 0:
+		return sourceALB, nil
+	case reqCtx.ConnectionID != "":
+		return sourceWebsocket, nil
+	case probe.Version == "2.0" && len(reqCtx.HTTP) > 0:
+		// Function URLs send the same payload as HTTP APIs but always from a lambda-url domain
+		if strings.Contains(reqCtx.DomainName, ".lambda-url.") {
+			return sourceFunctionURL, nil
+		}
+
+		return sourceAPIGatewayV2, nil
+	case probe.Version == "2.0" && probe.Method != "":
+		return sourceVPCLatticeV2, nil
+	case probe.RawPath != "" && probe.Method != "":
+		return sourceVPCLatticeV1, nil
+	case probe.Path != "" && probe.HTTPMethod == "" && probe.RawPath == "" && probe.RequestContext == nil:
+		// the method of the lamway envelope is optional
+		return sourceDirectInvoke, nil
+	case probe.HTTPMethod != "":
+		return sourceAPIGatewayV1, nil
+	default:
+		return sourceUnknown, ErrUnknownEventSource
+	}
+}
+
+func bindEvent[E any](raw json.RawMessage, adapter EventAdapter[E]) (boundEvent, error) {
+	var evt E
+
+	if err := json.Unmarshal(raw, &evt); err != nil {
+		return boundEvent{}, errors.Join(err, ErrUnknownEventSource)
+	}
+
+	return boundEvent{
+		evt: evt,
+		decode: func(ctx context.Context) (*http.Request, error) {
+			return adapter.DecodeRequest(ctx, evt)
+		},
+		encode: func(ctx context.Context, res response.APIGatewayResponse) (any, error) {
+			return adapter.EncodeResponse(ctx, evt, res)
+		},
+	}, nil
+}
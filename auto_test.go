@@ -0,0 +1,135 @@
+package lamway
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/danteay/lamway/request"
+	"github.com/danteay/lamway/response"
+)
+
+func TestAutoAdapter_detect(t *testing.T) {
+	cases := []struct {
+		name    string
+		payload string
+		want    any
+	}{
+		{
+			name:    "api gateway v1",
+			payload: `{"httpMethod":"GET","path":"/pets/luna","requestContext":{"requestId":"1234"}}`,
+			want:    events.APIGatewayProxyRequest{},
+		},
+		{
+			name:    "api gateway v2",
+			payload: `{"version":"2.0","rawPath":"/pets/luna","requestContext":{"domainName":"abc.execute-api.us-east-1.amazonaws.com","http":{"method":"GET"}}}`,
+			want:    events.APIGatewayV2HTTPRequest{},
+		},
+		{
+			name:    "function url",
+			payload: `{"version":"2.0","rawPath":"/pets/luna","requestContext":{"domainName":"abc.lambda-url.us-east-1.on.aws","http":{"method":"GET"}}}`,
+			want:    events.LambdaFunctionURLRequest{},
+		},
+		{
+			name:    "websocket",
+			payload: `{"requestContext":{"routeKey":"$connect","eventType":"CONNECT","connectionId":"conn-1"}}`,
+			want:    events.APIGatewayWebsocketProxyRequest{},
+		},
+		{
+			name:    "alb",
+			payload: `{"httpMethod":"GET","path":"/pets/luna","requestContext":{"elb":{"targetGroupArn":"arn"}}}`,
+			want:    events.ALBTargetGroupRequest{},
+		},
+		{
+			name:    "vpc lattice v1",
+			payload: `{"raw_path":"/pets/luna","method":"GET","headers":{}}`,
+			want:    request.VPCLatticeEventV1{},
+		},
+		{
+			name:    "vpc lattice v2",
+			payload: `{"version":"2.0","path":"/pets/luna","method":"GET","requestContext":{"serviceArn":"arn"}}`,
+			want:    request.VPCLatticeEventV2{},
+		},
+		{
+			name:    "direct invoke",
+			payload: `{"method":"GET","path":"/pets/luna?full=true","headers":{"Accept":"application/json"}}`,
+			want:    request.DirectInvokeEvent{},
+		},
+		{
+			name:    "direct invoke without method",
+			payload: `{"path":"/pets/luna"}`,
+			want:    request.DirectInvokeEvent{},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			b, err := AutoAdapter{}.detect(json.RawMessage(c.payload))
+
+			assert.NoError(t, err)
+			assert.IsType(t, c.want, b.evt)
+		})
+	}
+
+	t.Run("unknown", func(t *testing.T) {
+		_, err := AutoAdapter{}.detect(json.RawMessage(`{"Records":[]}`))
+		assert.ErrorIs(t, err, ErrUnknownEventSource)
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		_, err := AutoAdapter{}.detect(json.RawMessage(`[`))
+		assert.ErrorIs(t, err, ErrUnknownEventSource)
+	})
+}
+
+func TestAutoAdapter_EncodeResponse(t *testing.T) {
+	a := AutoAdapter{}
+
+	r, err := a.DecodeRequest(context.Background(), json.RawMessage(`{"httpMethod":"GET","path":"/pets/luna","requestContext":{"elb":{"targetGroupArn":"arn"}}}`))
+	assert.NoError(t, err)
+
+	// the event detected by DecodeRequest is reused instead of decoding the payload again
+	res, err := a.EncodeResponse(r.Context(), json.RawMessage(`[`), response.APIGatewayResponse{StatusCode: http.StatusOK})
+
+	assert.NoError(t, err)
+	assert.Contains(t, res, "statusDescription")
+}
+
+func TestNewAuto(t *testing.T) {
+	gw := NewAuto(WithHTTPHandler(http.HandlerFunc(hello)))
+
+	t.Run("should encode alb response", func(t *testing.T) {
+		payload, err := gw.invoke(context.Background(), json.RawMessage(`{"httpMethod":"GET","path":"/pets/luna","requestContext":{"elb":{"targetGroupArn":"arn"}}}`))
+
+		res, errMarshal := json.Marshal(payload)
+		if errMarshal != nil {
+			assert.Fail(t, "can't marshal payload", errMarshal)
+		}
+
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"body":"Hello World from Go\n", "headers":{"Content-Type":"text/plain; charset=utf8", "Custom-Header":"custom-value"}, "isBase64Encoded":false, "statusCode":200, "statusDescription":"200 OK"}`, string(res))
+	})
+
+	t.Run("should encode api gateway v2 response", func(t *testing.T) {
+		payload, err := gw.invoke(context.Background(), json.RawMessage(`{"version":"2.0","rawPath":"/pets/luna","requestContext":{"http":{"method":"GET"}}}`))
+
+		res, errMarshal := json.Marshal(payload)
+		if errMarshal != nil {
+			assert.Fail(t, "can't marshal payload", errMarshal)
+		}
+
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"body":"Hello World from Go\n", "cookies":null, "headers":{"Content-Type":"text/plain; charset=utf8", "Custom-Header":"custom-value"}, "isBase64Encoded":false, "multiValueHeaders":{}, "statusCode":200}`, string(res))
+	})
+
+	t.Run("should fail on unknown source", func(t *testing.T) {
+		res, err := gw.invoke(context.Background(), json.RawMessage(`{"Records":[]}`))
+
+		assert.ErrorIs(t, err, ErrUnknownEventSource)
+		assert.Equal(t, gw.defaultResponse.ToV1Map(), res)
+	})
+}
@@ -0,0 +1,161 @@
+package lamway
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/danteay/lamway/response"
+)
+
+// BatchAdapter translates events carrying several records into one http request per record, and the handler
+// responses back into the payload returned to Lambda.
+//
+// A record fails when its handler answers with a non 2xx status code or panics, and with Batch.StopOnFailure the rest
+// of its group is skipped and reported as failed too. The built-in adapters of queues and streams report the failed
+// records in `batchItemFailures`, so the function must be configured with the ReportBatchItemFailures response type.
+type BatchAdapter[T any] interface {
+	// DecodeBatch builds the http requests served by the handler from the event records.
+	DecodeBatch(ctx context.Context, evt T) (Batch, error)
+	// EncodeBatch builds the Lambda response payload from the results, which are in the same order as the requests.
+	EncodeBatch(ctx context.Context, evt T, results []BatchResult) (any, error)
+}
+
+// Batch is the set of requests built from the records of an event, and how they must be processed.
+type Batch struct {
+	Requests []BatchRequest
+	// Concurrency is the number of groups processed in parallel. Zero or one processes everything sequentially.
+	Concurrency int
+	// StopOnFailure skips the remaining requests of a group once one of them fails.
+	StopOnFailure bool
+}
+
+// BatchRequest is the request built from a single record.
+type BatchRequest struct {
+	// ID identifies the record in the response, e.g. the SQS message ID or the stream sequence number.
+	ID string
+	// Group is the ordering key of the record. Requests of the same group are processed sequentially in order, and
+	// requests without a group don't depend on any other.
+	Group   string
+	Request *http.Request
+}
+
+// BatchResult is the outcome of a BatchRequest.
+type BatchResult struct {
+	ID       string
+	Response response.APIGatewayResponse
+	// Skipped is true when the request wasn't processed because a previous one of its group failed.
+	Skipped bool
+}
+
+// Failed reports whether the request was skipped or the handler answered with a non 2xx status code.
+func (br BatchResult) Failed() bool {
+	return br.Skipped || failed(br.Response)
+}
+
+// WithBatchAdapter replaces the built-in BatchAdapter of the gateway events. The adapter must handle the same event
+// type the gateway is created for, otherwise the gateway fails with ErrAdapterMismatch.
+func WithBatchAdapter[T Event](a BatchAdapter[T]) Option {
+	return func(o *options) {
+		o.adapter = a
+	}
+}
+
+func (gw *Gateway[T]) invokeBatch(ctx context.Context, evt T) (any, error) {
+	batch, err := gw.batchAdapter.DecodeBatch(ctx, evt)
+	if err != nil {
+		return nil, err
+	}
+
+	results := gw.serveBatch(ctx, batch)
+
+	res, err := gw.batchAdapter.EncodeBatch(ctx, evt, results)
+
+	gw.logDebug("[%T] response: %+v", evt, res)
+
+	return res, err
+}
+
+// serveBatch runs every request of the batch through the configured http.Handler, keeping the order inside each
+// group and processing up to batch.Concurrency groups at the same time.
+func (gw *Gateway[T]) serveBatch(ctx context.Context, batch Batch) []BatchResult {
+	results := make([]BatchResult, len(batch.Requests))
+
+	groups := make([][]int, 0, len(batch.Requests))
+	groupIndex := make(map[string]int)
+
+	for i, br := range batch.Requests {
+		results[i].ID = br.ID
+
+		if br.Group == "" {
+			groups = append(groups, []int{i})
+			continue
+		}
+
+		idx, ok := groupIndex[br.Group]
+		if !ok {
+			idx = len(groups)
+			groupIndex[br.Group] = idx
+			groups = append(groups, nil)
+		}
+
+		groups[idx] = append(groups[idx], i)
+	}
+
+	serveGroup := func(group []int) {
+		for n, i := range group {
+			results[i].Response = gw.serveRecord(ctx, batch.Requests[i].Request)
+
+			if batch.StopOnFailure && results[i].Failed() {
+				for _, j := range group[n+1:] {
+					results[j].Skipped = true
+				}
+
+				return
+			}
+		}
+	}
+
+	if batch.Concurrency <= 1 {
+		for _, group := range groups {
+			serveGroup(group)
+		}
+
+		return results
+	}
+
+	var wg sync.WaitGroup
+
+	sem := make(chan struct{}, batch.Concurrency)
+
+	for _, group := range groups {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(group []int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			serveGroup(group)
+		}(group)
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+// serveRecord serves the request of a batch record, answering with the default 500 response when the handler panics
+// so the record is reported as failed instead of crashing the invocation.
+func (gw *Gateway[T]) serveRecord(ctx context.Context, r *http.Request) (res response.APIGatewayResponse) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			gw.logDebug("[%T] batch record panic: %v", *new(T), rec)
+			res = gw.defaultResponse
+		}
+	}()
+
+	return gw.serve(ctx, r)
+}
@@ -0,0 +1,104 @@
+package lamway
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGateway_serveBatch(t *testing.T) {
+	newBatch := func(groups ...string) Batch {
+		batch := Batch{}
+
+		for i, g := range groups {
+			req := httptest.NewRequest(http.MethodPost, "/records", nil)
+			req.Header.Set("X-Record", string(rune('a'+i)))
+
+			batch.Requests = append(batch.Requests, BatchRequest{ID: string(rune('a' + i)), Group: g, Request: req})
+		}
+
+		return batch
+	}
+
+	t.Run("keeps order and stops failed groups", func(t *testing.T) {
+		var (
+			mu   sync.Mutex
+			seen []string
+		)
+
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get("X-Record")
+
+			mu.Lock()
+			seen = append(seen, id)
+			mu.Unlock()
+
+			if id == "b" {
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+		}
+
+		gw := New[json.RawMessage](WithHTTPHandler(http.HandlerFunc(handler)))
+
+		batch := newBatch("g1", "g1", "g1", "g2")
+		batch.StopOnFailure = true
+
+		results := gw.serveBatch(context.Background(), batch)
+
+		assert.Equal(t, []string{"a", "b", "d"}, seen)
+		assert.Len(t, results, 4)
+		assert.False(t, results[0].Failed())
+		assert.True(t, results[1].Failed())
+		assert.True(t, results[2].Skipped)
+		assert.Equal(t, "c", results[2].ID)
+		assert.False(t, results[3].Failed())
+	})
+
+	t.Run("bounded concurrency", func(t *testing.T) {
+		var running, peak int32
+
+		release := make(chan struct{})
+
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			n := atomic.AddInt32(&running, 1)
+			defer atomic.AddInt32(&running, -1)
+
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+
+			<-release
+		}
+
+		gw := New[json.RawMessage](WithHTTPHandler(http.HandlerFunc(handler)))
+
+		batch := newBatch("", "", "", "", "")
+		batch.Concurrency = 2
+
+		done := make(chan []BatchResult)
+
+		go func() {
+			done <- gw.serveBatch(context.Background(), batch)
+		}()
+
+		close(release)
+
+		results := <-done
+
+		assert.Len(t, results, 5)
+		assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(2))
+
+		for _, r := range results {
+			assert.False(t, r.Failed())
+		}
+	})
+}
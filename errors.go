@@ -4,4 +4,16 @@ import "errors"
 
 var (
 	ErrInvalidAPIGatewayRequest = errors.New("gateway: invalid APIGateway request struct configured")
+	ErrUnknownEventSource       = errors.New("gateway: unable to detect the event source")
+	ErrAdapterMismatch          = errors.New("gateway: adapter doesn't match the gateway event type")
+
+	// ErrUnauthorized is returned by Lambda authorizers to make API Gateway answer with a 401. API Gateway matches
+	// the exact error message.
+	ErrUnauthorized = errors.New("Unauthorized")
+
+	ErrInvalidAuthorizerResponse   = errors.New("gateway: handler didn't answer with an authorizer policy")
+	ErrNoRoute                     = errors.New("gateway: no route matches the event")
+	ErrHandlerFailed               = errors.New("gateway: handler answered with a non 2xx status code")
+	ErrResponseUploadFailed        = errors.New("gateway: response upload failed")
+	ErrMissingObjectResponseWriter = errors.New("gateway: S3 Object Lambda adapter without ObjectResponseWriter")
 )
@@ -0,0 +1,44 @@
+package lamway
+
+import (
+	"encoding/json"
+
+	"github.com/aws/aws-lambda-go/cfn"
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/danteay/lamway/request"
+)
+
+// Event is the set of event types a Gateway can be created for. Using any other type as the Gateway type parameter,
+// like `lamway.New[string]()`, fails at build time instead of on the first invocation. json.RawMessage is used by
+// NewAuto to detect the source on every invocation, and by custom EventAdapter implementations to decode their own
+// envelopes.
+type Event interface {
+	events.APIGatewayProxyRequest |
+		events.APIGatewayV2HTTPRequest |
+		events.APIGatewayWebsocketProxyRequest |
+		events.LambdaFunctionURLRequest |
+		events.ALBTargetGroupRequest |
+		events.APIGatewayCustomAuthorizerRequestTypeRequest |
+		events.APIGatewayCustomAuthorizerRequest |
+		events.APIGatewayV2CustomAuthorizerV2Request |
+		request.VPCLatticeEventV1 |
+		request.VPCLatticeEventV2 |
+		request.CloudFrontEvent |
+		request.BedrockAgentEvent |
+		request.AppSyncResolverEvent |
+		request.DirectInvokeEvent |
+		events.SQSEvent |
+		events.KinesisEvent |
+		events.DynamoDBEvent |
+		events.KafkaEvent |
+		events.KinesisFirehoseEvent |
+		cfn.Event |
+		request.StepFunctionsTask |
+		events.S3ObjectLambdaEvent |
+		events.EventBridgeEvent |
+		events.SNSEvent |
+		events.S3Event |
+		[]request.AppSyncResolverEvent |
+		json.RawMessage
+}
@@ -0,0 +1,10 @@
+module streamingexample
+
+go 1.20
+
+replace github.com/danteay/lamway => ../../
+
+require (
+	github.com/aws/aws-lambda-go v1.49.0
+	github.com/danteay/lamway v0.0.0-00010101000000-000000000000
+)
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/danteay/lamway"
+)
+
+func init() {
+	http.HandleFunc("/", countdown)
+}
+
+// build with `-tags lambda.norpc` and deploy behind a Function URL with the RESPONSE_STREAM invoke mode
+func main() {
+	gw := lamway.New[events.LambdaFunctionURLRequest](lamway.WithResponseStreaming())
+	log.Fatal(gw.Start())
+}
+
+func countdown(w http.ResponseWriter, _ *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+
+	for i := 5; i > 0; i-- {
+		_, _ = fmt.Fprintf(w, "%d...\n", i)
+		flusher.Flush()
+
+		time.Sleep(time.Second)
+	}
+
+	_, _ = fmt.Fprintln(w, "Hello World from Go")
+}
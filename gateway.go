@@ -9,7 +9,6 @@ import (
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
 
-	"github.com/danteay/lamway/request"
 	"github.com/danteay/lamway/response"
 )
 
@@ -22,18 +21,22 @@ type HandlerProvider func(ctx context.Context) http.Handler
 type Decorator func(handler any) any
 
 // Gateway wraps an http handler to enable use as a lambda.Handler
-type Gateway[T any] struct {
+type Gateway[T Event] struct {
 	handler         http.Handler
 	handlerProvider HandlerProvider
 	hpOnce          *sync.Once
 	decorators      []Decorator
 	defaultResponse response.APIGatewayResponse
 	logger          Logger
+	adapter         EventAdapter[T]
+	batchAdapter    BatchAdapter[T]
+	streaming       bool
+	err             error
 }
 
 // New creates a gateway using the provided http.Handler enabling use in existing aws-lambda-go
 // projects
-func New[T any](opts ...Option) *Gateway[T] {
+func New[T Event](opts ...Option) *Gateway[T] {
 	gatewayOpts := options{
 		httpHandler:     http.DefaultServeMux,
 		defaultHeaders:  map[string]string{"Content-Type": "application/json"},
@@ -44,11 +47,12 @@ func New[T any](opts ...Option) *Gateway[T] {
 		opt(&gatewayOpts)
 	}
 
-	return &Gateway[T]{
+	gw := &Gateway[T]{
 		handler:         gatewayOpts.httpHandler,
 		handlerProvider: gatewayOpts.handlerProvider,
 		decorators:      gatewayOpts.decorators,
 		logger:          gatewayOpts.logger,
+		streaming:       gatewayOpts.streaming,
 		hpOnce:          &sync.Once{},
 		defaultResponse: response.APIGatewayResponse{
 			StatusCode: http.StatusInternalServerError,
@@ -56,13 +60,43 @@ func New[T any](opts ...Option) *Gateway[T] {
 			Body:       gatewayOpts.defaultErrorRes,
 		},
 	}
+
+	gw.setAdapter(defaultAdapter[T](gatewayOpts))
+
+	if gatewayOpts.adapter != nil && !gw.setAdapter(gatewayOpts.adapter) {
+		gw.err = fmt.Errorf("%w: %T can't handle %T events", ErrAdapterMismatch, gatewayOpts.adapter, *new(T))
+	}
+
+	return gw
+}
+
+// setAdapter sets a as the EventAdapter or BatchAdapter of the gateway, returning false if it's neither for T.
+func (gw *Gateway[T]) setAdapter(a any) bool {
+	switch v := a.(type) {
+	case EventAdapter[T]:
+		gw.adapter, gw.batchAdapter = v, nil
+	case BatchAdapter[T]:
+		gw.adapter, gw.batchAdapter = nil, v
+	default:
+		return false
+	}
+
+	return true
 }
 
 // GetInvoker returns the function that will be invoked by the lambda.Start call in the main function. This function will be
 // decorated or not depending on the options passed to the New function.
+//
+// Gateways whose responses are always a map, like the API Gateway, ALB, VPC Lattice and non streaming Function URL
+// ones, hand a `func(context.Context, T) (map[string]any, error)` to the decorators. Any other gateway hands a
+// `func(context.Context, T) (any, error)`, as its responses can be policies, batch reports or response streams.
 func (gw *Gateway[T]) GetInvoker() any {
 	var worker any = gw.invoke
 
+	if gw.returnsMap() {
+		worker = gw.invokeMap
+	}
+
 	if len(gw.decorators) > 0 {
 		for _, decorator := range gw.decorators {
 			worker = decorator(worker)
@@ -86,74 +120,141 @@ func (gw *Gateway[T]) Start() (err error) {
 		}
 	}()
 
+	if gw.err != nil {
+		return gw.err
+	}
+
 	lambda.Start(gw.GetInvoker())
 
 	return nil
 }
 
-func (gw *Gateway[T]) invoke(ctx context.Context, evt T) (map[string]any, error) {
-	aux := any(evt)
+func (gw *Gateway[T]) invoke(ctx context.Context, evt T) (any, error) {
+	if gw.err != nil {
+		return gw.defaultResponse.ToV1Map(), gw.err
+	}
+
+	gw.logDebug("[%T] request: %+v", evt, evt)
+
+	if gw.batchAdapter != nil {
+		return gw.invokeBatch(ctx, evt)
+	}
+
+	if s, ok := gw.adapter.(StreamEncoder[T]); ok {
+		return gw.stream(ctx, evt, s.EncodeStream)
+	}
 
-	switch v := aux.(type) {
-	case events.APIGatewayProxyRequest:
-		gw.logDebug("[id:%s] v1 request: %+v", v.RequestContext.RequestID, aux)
+	if s, ok := gw.adapter.(StreamingAdapter[T]); ok && gw.streaming && s.SupportsStreaming(evt) {
+		return gw.stream(ctx, evt, returnStream[T])
+	}
+
+	r, err := gw.adapter.DecodeRequest(ctx, evt)
+	if err != nil {
+		res, _ := gw.adapter.EncodeResponse(ctx, evt, gw.defaultResponse)
+		return res, err
+	}
+
+	res, err := gw.adapter.EncodeResponse(r.Context(), evt, gw.serve(ctx, r))
 
-		res, err := gw.handlerV1(ctx, v)
-		apiRes := res.ToV1Map()
+	gw.logDebug("[%T] response: %+v", evt, res)
 
-		gw.logDebug("[id:%s] v1 response: %+v", v.RequestContext.RequestID, apiRes)
+	return res, err
+}
 
-		return apiRes, err
-	case events.APIGatewayV2HTTPRequest:
-		gw.logDebug("[id:%s] v2 request: %+v", v.RequestContext.RequestID, aux)
+// invokeMap is the invoker of gateways whose responses are always a map[string]any.
+func (gw *Gateway[T]) invokeMap(ctx context.Context, evt T) (map[string]any, error) {
+	res, err := gw.invoke(ctx, evt)
 
-		res, err := gw.handlerV2(ctx, v)
-		apiRes := res.ToV2Map()
+	m, _ := res.(map[string]any)
 
-		gw.logDebug("[id:%s] v2 response: %+v", v.RequestContext.RequestID, apiRes)
+	return m, err
+}
 
-		return apiRes, err
+// returnsMap reports whether every response of the gateway is a map[string]any.
+func (gw *Gateway[T]) returnsMap() bool {
+	switch any(gw.adapter).(type) {
+	case APIGatewayV1Adapter, APIGatewayV2Adapter, WebsocketAdapter, ALBAdapter, VPCLatticeV1Adapter, VPCLatticeV2Adapter:
+		return true
+	case FunctionURLAdapter:
+		return !gw.streaming
 	default:
-		return gw.defaultResponse.ToV1Map(), ErrInvalidAPIGatewayRequest
+		return false
 	}
 }
 
-func (gw *Gateway[T]) handlerV1(ctx context.Context, evt events.APIGatewayProxyRequest) (response.APIGatewayResponse, error) {
-	r, err := request.NewV1(ctx, evt)
+// stream runs the translated request through the configured http.Handler sending its output through a Lambda
+// response stream.
+func (gw *Gateway[T]) stream(ctx context.Context, evt T, encode streamEncodeFunc[T]) (any, error) {
+	r, err := gw.adapter.DecodeRequest(ctx, evt)
 	if err != nil {
-		return gw.defaultResponse, err
+		res, _ := gw.adapter.EncodeResponse(ctx, evt, gw.defaultResponse)
+		return res, err
 	}
 
-	w := response.New()
+	w := response.NewStream()
+	handler := gw.httpHandler(ctx)
+	done := make(chan struct{})
 
-	if gw.handlerProvider != nil {
-		gw.hpOnce.Do(func() {
-			gw.handler = gw.handlerProvider(ctx)
-		})
-	}
+	go func() {
+		defer close(done)
 
-	gw.handler.ServeHTTP(w, r)
+		defer func() {
+			if rec := recover(); rec != nil {
+				_ = w.CloseWithError(fmt.Errorf("%v", rec))
+				return
+			}
 
-	return w.End(), nil
-}
+			_ = w.Close()
+		}()
 
-func (gw *Gateway[T]) handlerV2(ctx context.Context, evt events.APIGatewayV2HTTPRequest) (response.APIGatewayResponse, error) {
-	r, err := request.NewV2(ctx, evt)
-	if err != nil {
-		return gw.defaultResponse, err
+		handler.ServeHTTP(w, r)
+	}()
+
+	// the runtime stops reading when the invocation ends, so a handler still writing would block forever
+	go func() {
+		select {
+		case <-ctx.Done():
+			w.Cancel(ctx.Err())
+		case <-done:
+		}
+	}()
+
+	// the status code and headers must be known before handing the stream over
+	select {
+	case <-w.Ready():
+	case <-ctx.Done():
+		res, _ := gw.adapter.EncodeResponse(ctx, evt, gw.defaultResponse)
+		return res, ctx.Err()
 	}
 
+	return encode(r.Context(), evt, w.Response())
+}
+
+type streamEncodeFunc[T any] func(ctx context.Context, evt T, res *events.LambdaFunctionURLStreamingResponse) (any, error)
+
+// returnStream hands the stream to the Lambda runtime, which sends it to the client.
+func returnStream[T any](_ context.Context, _ T, res *events.LambdaFunctionURLStreamingResponse) (any, error) {
+	return res, nil
+}
+
+// serve runs the translated request through the configured http.Handler and returns the captured response.
+func (gw *Gateway[T]) serve(ctx context.Context, r *http.Request) response.APIGatewayResponse {
 	w := response.New()
 
+	gw.httpHandler(ctx).ServeHTTP(w, r)
+
+	return w.End()
+}
+
+// httpHandler returns the configured http.Handler, initializing it through the HandlerProvider on the first call.
+func (gw *Gateway[T]) httpHandler(ctx context.Context) http.Handler {
 	if gw.handlerProvider != nil {
 		gw.hpOnce.Do(func() {
 			gw.handler = gw.handlerProvider(ctx)
 		})
 	}
 
-	gw.handler.ServeHTTP(w, r)
-
-	return w.End(), nil
+	return gw.handler
 }
 
 func (gw *Gateway[T]) logDebug(format string, args ...any) {
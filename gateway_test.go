@@ -4,13 +4,16 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/danteay/lamway/request"
+	"github.com/danteay/lamway/websocket"
 )
 
 const testPath = "/pets/luna"
@@ -65,6 +68,150 @@ func TestGateway_invoke(t *testing.T) {
 		assert.JSONEq(t, `{"body":"Hello World from Go\n", "cookies":null, "headers":{"Content-Type":"text/plain; charset=utf8", "Custom-Header":"custom-value"}, "isBase64Encoded":false, "multiValueHeaders":{}, "statusCode":200}`, string(res))
 	})
 
+	t.Run("should execute alb", func(t *testing.T) {
+		evt := events.ALBTargetGroupRequest{
+			Path:       testPath,
+			HTTPMethod: http.MethodPost,
+			Headers:    map[string]string{"X-Forwarded-For": "1.2.3.4"},
+		}
+
+		gw := New[events.ALBTargetGroupRequest](WithHTTPHandler(http.HandlerFunc(hello)))
+
+		payload, err := gw.invoke(context.Background(), evt)
+
+		res, err := json.Marshal(payload)
+		if err != nil {
+			assert.Fail(t, "can't marshal payload", err)
+		}
+
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"body":"Hello World from Go\n", "headers":{"Content-Type":"text/plain; charset=utf8", "Custom-Header":"custom-value"}, "isBase64Encoded":false, "statusCode":200, "statusDescription":"200 OK"}`, string(res))
+	})
+
+	t.Run("should execute alb with multi value headers", func(t *testing.T) {
+		evt := events.ALBTargetGroupRequest{
+			Path:              testPath,
+			HTTPMethod:        http.MethodPost,
+			MultiValueHeaders: map[string][]string{"X-Forwarded-For": {"1.2.3.4"}},
+		}
+
+		gw := New[events.ALBTargetGroupRequest](WithHTTPHandler(http.HandlerFunc(hello)))
+
+		payload, err := gw.invoke(context.Background(), evt)
+
+		res, err := json.Marshal(payload)
+		if err != nil {
+			assert.Fail(t, "can't marshal payload", err)
+		}
+
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"body":"Hello World from Go\n", "multiValueHeaders":{"Content-Type":["text/plain; charset=utf8"], "Custom-Header":["custom-value"]}, "isBase64Encoded":false, "statusCode":200, "statusDescription":"200 OK"}`, string(res))
+	})
+
+	t.Run("should execute function url", func(t *testing.T) {
+		evt := events.LambdaFunctionURLRequest{
+			RawPath: testPath,
+			RequestContext: events.LambdaFunctionURLRequestContext{
+				HTTP: events.LambdaFunctionURLRequestContextHTTPDescription{
+					Method: http.MethodPost,
+					Path:   testPath,
+				},
+				Authorizer: &events.LambdaFunctionURLRequestContextAuthorizerDescription{
+					IAM: &events.LambdaFunctionURLRequestContextAuthorizerIAMDescription{
+						UserARN: "arn:aws:iam::123456789012:user/luna",
+					},
+				},
+			},
+		}
+
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			iam, ok := request.FunctionURLIAM(r.Context())
+			if !ok {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+
+			w.Header().Set("X-Caller", iam.UserARN)
+			hello(w, r)
+		}
+
+		gw := New[events.LambdaFunctionURLRequest](WithHTTPHandler(http.HandlerFunc(handler)))
+
+		payload, err := gw.invoke(context.Background(), evt)
+
+		res, err := json.Marshal(payload)
+		if err != nil {
+			assert.Fail(t, "can't marshal payload", err)
+		}
+
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"body":"Hello World from Go\n", "cookies":null, "headers":{"Content-Type":"text/plain; charset=utf8", "Custom-Header":"custom-value", "X-Caller":"arn:aws:iam::123456789012:user/luna"}, "isBase64Encoded":false, "statusCode":200}`, string(res))
+	})
+
+	t.Run("should execute vpc lattice v1", func(t *testing.T) {
+		evt := request.VPCLatticeEventV1{
+			RawPath: testPath,
+			Method:  http.MethodPost,
+		}
+
+		gw := New[request.VPCLatticeEventV1](WithHTTPHandler(http.HandlerFunc(hello)))
+
+		payload, err := gw.invoke(context.Background(), evt)
+
+		res, err := json.Marshal(payload)
+		if err != nil {
+			assert.Fail(t, "can't marshal payload", err)
+		}
+
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"body":"Hello World from Go\n", "headers":{"Content-Type":"text/plain; charset=utf8", "Custom-Header":"custom-value"}, "isBase64Encoded":false, "statusCode":200, "statusDescription":"200 OK"}`, string(res))
+	})
+
+	t.Run("should execute vpc lattice v2", func(t *testing.T) {
+		evt := request.VPCLatticeEventV2{
+			Version: "2.0",
+			Path:    testPath,
+			Method:  http.MethodPost,
+		}
+
+		gw := New[request.VPCLatticeEventV2](WithHTTPHandler(http.HandlerFunc(hello)))
+
+		payload, err := gw.invoke(context.Background(), evt)
+
+		res, err := json.Marshal(payload)
+		if err != nil {
+			assert.Fail(t, "can't marshal payload", err)
+		}
+
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"body":"Hello World from Go\n", "headers":{"Content-Type":"text/plain; charset=utf8", "Custom-Header":"custom-value"}, "isBase64Encoded":false, "statusCode":200, "statusDescription":"200 OK"}`, string(res))
+	})
+
+	t.Run("should execute websocket", func(t *testing.T) {
+		evt := events.APIGatewayWebsocketProxyRequest{
+			RequestContext: events.APIGatewayWebsocketProxyRequestContext{
+				RouteKey:     "$default",
+				EventType:    "MESSAGE",
+				ConnectionID: "conn-1",
+			},
+		}
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/$default", hello)
+
+		gw := New[events.APIGatewayWebsocketProxyRequest](WithHTTPHandler(mux))
+
+		payload, err := gw.invoke(context.Background(), evt)
+
+		res, err := json.Marshal(payload)
+		if err != nil {
+			assert.Fail(t, "can't marshal payload", err)
+		}
+
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"body":"Hello World from Go\n", "headers":{"Content-Type":"text/plain; charset=utf8", "Custom-Header":"custom-value"}, "isBase64Encoded":false, "statusCode":200}`, string(res))
+	})
+
 	t.Run("should get error bay error parsing path on v1", func(t *testing.T) {
 		evt := events.APIGatewayProxyRequest{
 			Path:       testPath + string(rune(0x7f)),
@@ -178,3 +325,208 @@ func TestGateway_WithHandlerProvider(t *testing.T) {
 		assert.Equal(t, 1, called, "handler provider should be called exactly once")
 	})
 }
+
+func TestGateway_WithResponseStreaming(t *testing.T) {
+	evt := events.LambdaFunctionURLRequest{
+		RawPath: testPath,
+		RequestContext: events.LambdaFunctionURLRequestContext{
+			HTTP: events.LambdaFunctionURLRequestContextHTTPDescription{
+				Method: http.MethodGet,
+				Path:   testPath,
+			},
+		},
+	}
+
+	t.Run("should stream function url response", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, _ *http.Request) {
+			flusher, ok := w.(http.Flusher)
+			if !ok {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			w.Header().Set("Content-Type", "text/plain")
+
+			for i := 0; i < 3; i++ {
+				_, _ = fmt.Fprintf(w, "chunk %d\n", i)
+				flusher.Flush()
+			}
+		}
+
+		gw := New[events.LambdaFunctionURLRequest](WithHTTPHandler(http.HandlerFunc(handler)), WithResponseStreaming())
+
+		payload, err := gw.invoke(context.Background(), evt)
+		assert.NoError(t, err)
+
+		res, ok := payload.(*events.LambdaFunctionURLStreamingResponse)
+		if !ok {
+			t.Fatalf("unexpected payload type %T", payload)
+		}
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "text/plain", res.Headers["Content-Type"])
+
+		body, err := io.ReadAll(res.Body)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "chunk 0\nchunk 1\nchunk 2\n", string(body))
+	})
+
+	t.Run("should fail stream on handler panic", func(t *testing.T) {
+		handler := func(_ http.ResponseWriter, _ *http.Request) {
+			panic("boom")
+		}
+
+		gw := New[events.LambdaFunctionURLRequest](WithHTTPHandler(http.HandlerFunc(handler)), WithResponseStreaming())
+
+		payload, err := gw.invoke(context.Background(), evt)
+		assert.NoError(t, err)
+
+		res, ok := payload.(*events.LambdaFunctionURLStreamingResponse)
+		if !ok {
+			t.Fatalf("unexpected payload type %T", payload)
+		}
+
+		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+
+		_, err = io.ReadAll(res.Body)
+		assert.EqualError(t, err, "boom")
+	})
+
+	t.Run("should stop waiting for a silent handler when the invocation ends", func(t *testing.T) {
+		handler := func(_ http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}
+
+		gw := New[events.LambdaFunctionURLRequest](WithHTTPHandler(http.HandlerFunc(handler)), WithResponseStreaming())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		_, err := gw.invoke(ctx, evt)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("should unblock the handler when the stream isn't read anymore", func(t *testing.T) {
+		writeErr := make(chan error, 1)
+
+		handler := func(w http.ResponseWriter, _ *http.Request) {
+			flusher, _ := w.(http.Flusher)
+
+			for {
+				if _, err := w.Write([]byte("chunk\n")); err != nil {
+					writeErr <- err
+					return
+				}
+
+				flusher.Flush()
+			}
+		}
+
+		gw := New[events.LambdaFunctionURLRequest](WithHTTPHandler(http.HandlerFunc(handler)), WithResponseStreaming())
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		payload, err := gw.invoke(ctx, evt)
+		assert.NoError(t, err)
+
+		res, _ := payload.(*events.LambdaFunctionURLStreamingResponse)
+
+		_, _ = res.Body.Read(make([]byte, 6))
+
+		// like the runtime after a client disconnect
+		cancel()
+
+		select {
+		case err := <-writeErr:
+			assert.ErrorIs(t, err, context.Canceled)
+		case <-time.After(time.Second):
+			t.Fatal("handler still blocked writing the stream")
+		}
+	})
+
+	t.Run("should buffer other event sources", func(t *testing.T) {
+		gw := New[events.APIGatewayProxyRequest](WithHTTPHandler(http.HandlerFunc(hello)), WithResponseStreaming())
+
+		payload, err := gw.invoke(context.Background(), events.APIGatewayProxyRequest{Path: testPath, HTTPMethod: http.MethodGet})
+
+		assert.NoError(t, err)
+		assert.IsType(t, map[string]any{}, payload)
+	})
+}
+
+func TestGateway_WithConnectionManager(t *testing.T) {
+	conns := websocket.NewMemoryConnectionManager()
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		m, ok := websocket.FromContext(r.Context())
+		if !ok {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if err := m.PostToConnection(r.Context(), r.Header.Get(request.HeaderConnectionID), []byte("pong")); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+
+	gw := New[events.APIGatewayWebsocketProxyRequest](
+		WithHTTPHandler(http.HandlerFunc(handler)),
+		WithConnectionManager(conns),
+	)
+
+	evt := events.APIGatewayWebsocketProxyRequest{
+		Body: "ping",
+		RequestContext: events.APIGatewayWebsocketProxyRequestContext{
+			RouteKey:     "ping",
+			EventType:    "MESSAGE",
+			ConnectionID: "conn-1",
+		},
+	}
+
+	payload, err := gw.invoke(context.Background(), evt)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, payload.(map[string]any)["statusCode"])
+	assert.Equal(t, [][]byte{[]byte("pong")}, conns.Messages("conn-1"))
+}
+
+func TestGateway_GetInvoker(t *testing.T) {
+	t.Run("should keep the map signature for api gateway events", func(t *testing.T) {
+		var got any
+
+		gw := New[events.APIGatewayProxyRequest](
+			WithHTTPHandler(http.HandlerFunc(hello)),
+			WithDecorator(func(handler any) any {
+				got = handler
+				return handler
+			}),
+		)
+
+		invoker, ok := gw.GetInvoker().(func(context.Context, events.APIGatewayProxyRequest) (map[string]any, error))
+		assert.True(t, ok)
+		assert.IsType(t, invoker, got)
+
+		payload, err := invoker(context.Background(), events.APIGatewayProxyRequest{Path: testPath, HTTPMethod: http.MethodGet})
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, payload["statusCode"])
+	})
+
+	t.Run("should return any for streaming function urls", func(t *testing.T) {
+		gw := New[events.LambdaFunctionURLRequest](WithHTTPHandler(http.HandlerFunc(hello)), WithResponseStreaming())
+
+		_, ok := gw.GetInvoker().(func(context.Context, events.LambdaFunctionURLRequest) (any, error))
+		assert.True(t, ok)
+	})
+
+	t.Run("should return any for other event sources", func(t *testing.T) {
+		gw := New[events.SQSEvent](WithHTTPHandler(http.HandlerFunc(hello)))
+
+		_, ok := gw.GetInvoker().(func(context.Context, events.SQSEvent) (any, error))
+		assert.True(t, ok)
+	})
+}
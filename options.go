@@ -2,6 +2,8 @@ package lamway
 
 import (
 	"net/http"
+
+	"github.com/danteay/lamway/websocket"
 )
 
 type options struct {
@@ -11,6 +13,9 @@ type options struct {
 	defaultHeaders  map[string]string
 	defaultErrorRes string
 	logger          Logger
+	streaming       bool
+	connManager     websocket.ConnectionManager
+	adapter         any
 }
 
 // Option is a functional option for configuring the gateway.
@@ -71,3 +76,31 @@ func WithHandlerProvider(p HandlerProvider) Option {
 		o.handlerProvider = p
 	}
 }
+
+// WithResponseStreaming enables streaming responses for Lambda Function URLs configured with the RESPONSE_STREAM
+// invoke mode. The http.ResponseWriter passed to the handler implements http.Flusher, and flushed bytes are sent to the
+// client right away. Other event sources keep using buffered responses.
+//
+// Response streaming requires the `provided.al2` or `provided.al2023` runtimes, or building with `-tags lambda.norpc`.
+func WithResponseStreaming() Option {
+	return func(o *options) {
+		o.streaming = true
+	}
+}
+
+// WithConnectionManager sets the websocket.ConnectionManager added to the context of WebSocket requests. By default a
+// websocket.HTTPConnectionManager pointing to the API that sent the event is used.
+func WithConnectionManager(m websocket.ConnectionManager) Option {
+	return func(o *options) {
+		o.connManager = m
+	}
+}
+
+// WithAdapter replaces the built-in EventAdapter of the gateway events. The adapter must handle the same event type
+// the gateway is created for, e.g. `lamway.New[json.RawMessage](lamway.WithAdapter[json.RawMessage](myAdapter))` to
+// support a custom envelope, otherwise the gateway fails with ErrAdapterMismatch.
+func WithAdapter[T Event](a EventAdapter[T]) Option {
+	return func(o *options) {
+		o.adapter = a
+	}
+}
@@ -0,0 +1,230 @@
+package request
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// AppSyncResolverEvent is the event sent by AppSync to a direct Lambda resolver. Batched resolvers receive a list of
+// them.
+type AppSyncResolverEvent struct {
+	Arguments map[string]any          `json:"arguments"`
+	Identity  *AppSyncRequestIdentity `json:"identity"`
+	Source    map[string]any          `json:"source"`
+	Request   AppSyncRequest          `json:"request"`
+	Prev      *AppSyncPrev            `json:"prev"`
+	Info      AppSyncInfo             `json:"info"`
+	Stash     map[string]any          `json:"stash"`
+}
+
+// AppSyncRequestIdentity is the caller identity. The fields set depend on the authorization type of the API.
+type AppSyncRequestIdentity struct {
+	// AWS_IAM
+	AccountID                   string `json:"accountId,omitempty"`
+	CognitoIdentityPoolID       string `json:"cognitoIdentityPoolId,omitempty"`
+	CognitoIdentityID           string `json:"cognitoIdentityId,omitempty"`
+	CognitoIdentityAuthType     string `json:"cognitoIdentityAuthType,omitempty"`
+	CognitoIdentityAuthProvider string `json:"cognitoIdentityAuthProvider,omitempty"`
+	UserARN                     string `json:"userArn,omitempty"`
+	// AMAZON_COGNITO_USER_POOLS and OPENID_CONNECT
+	Sub                 string         `json:"sub,omitempty"`
+	Issuer              string         `json:"issuer,omitempty"`
+	Username            string         `json:"username,omitempty"`
+	Claims              map[string]any `json:"claims,omitempty"`
+	DefaultAuthStrategy string         `json:"defaultAuthStrategy,omitempty"`
+	Groups              []string       `json:"groups,omitempty"`
+	// AWS_LAMBDA
+	ResolverContext map[string]any `json:"resolverContext,omitempty"`
+
+	SourceIP []string `json:"sourceIp,omitempty"`
+}
+
+// AppSyncRequest contains the headers of the GraphQL request.
+type AppSyncRequest struct {
+	Headers    map[string]string `json:"headers"`
+	DomainName string            `json:"domainName"`
+}
+
+// AppSyncPrev holds the result of the previous function of a pipeline resolver.
+type AppSyncPrev struct {
+	Result any `json:"result"`
+}
+
+// AppSyncInfo describes the field being resolved.
+type AppSyncInfo struct {
+	SelectionSetList    []string       `json:"selectionSetList"`
+	SelectionSetGraphQL string         `json:"selectionSetGraphQL"`
+	ParentTypeName      string         `json:"parentTypeName"`
+	FieldName           string         `json:"fieldName"`
+	Variables           map[string]any `json:"variables"`
+}
+
+// AppSyncRequestContext is the value added to the request context under ContextKey for AppSync resolver events.
+type AppSyncRequestContext struct {
+	Identity *AppSyncRequestIdentity
+	Source   map[string]any
+	Prev     *AppSyncPrev
+	Info     AppSyncInfo
+	Stash    map[string]any
+}
+
+// AppSyncIdentity returns the caller identity of an AppSync resolver request. The second value is false when the
+// request didn't come from AppSync or the API allows unauthenticated calls.
+func AppSyncIdentity(ctx context.Context) (*AppSyncRequestIdentity, bool) {
+	reqCtx, ok := ctx.Value(ContextKey).(AppSyncRequestContext)
+	if !ok || reqCtx.Identity == nil {
+		return nil, false
+	}
+
+	return reqCtx.Identity, true
+}
+
+// AppSyncQueryFunc builds the GraphQL query sent to the handler to resolve the field of an AppSync event.
+type AppSyncQueryFunc func(evt AppSyncResolverEvent) (string, error)
+
+// GraphQLEnum is an argument value inlined by AppSyncQuery as a GraphQL enum value, e.g. `status: ACTIVE`, instead of
+// a string.
+type GraphQLEnum string
+
+// AppSyncQuery is the default AppSyncQueryFunc. It rebuilds the query resolving the field of the event with the
+// arguments inlined, e.g. `query { getPost(id: "1") { id title } }`.
+//
+// The event doesn't carry the schema, so AppSync enums arrive as strings and are inlined as GraphQL strings, which
+// servers reject. A custom AppSyncQueryFunc can replace them with GraphQLEnum values before calling AppSyncQuery.
+// Fields of types other than Query, Mutation and Subscription can't be queried from the root of the schema and fail
+// with ErrUnsupportedAppSyncField, they need a custom AppSyncQueryFunc mapping them to a root field from the Source of
+// the event.
+func AppSyncQuery(evt AppSyncResolverEvent) (string, error) {
+	var operation string
+
+	switch evt.Info.ParentTypeName {
+	case "Query":
+		operation = "query"
+	case "Mutation":
+		operation = "mutation"
+	case "Subscription":
+		operation = "subscription"
+	default:
+		return "", fmt.Errorf("%w: %s.%s", ErrUnsupportedAppSyncField, evt.Info.ParentTypeName, evt.Info.FieldName)
+	}
+
+	if !isGraphQLName(evt.Info.FieldName) {
+		return "", fmt.Errorf("%w: invalid field name %q", ErrUnsupportedAppSyncField, evt.Info.FieldName)
+	}
+
+	var b strings.Builder
+
+	b.WriteString(operation + " { " + evt.Info.FieldName)
+
+	if len(evt.Arguments) > 0 {
+		args, err := graphQLLiteral(evt.Arguments)
+		if err != nil {
+			return "", err
+		}
+
+		// object literal braces become the argument list parenthesis
+		b.WriteString("(" + args[1:len(args)-1] + ")")
+	}
+
+	if selection := strings.TrimSpace(evt.Info.SelectionSetGraphQL); selection != "" {
+		b.WriteString(" " + selection)
+	}
+
+	b.WriteString(" }")
+
+	return b.String(), nil
+}
+
+// graphQLLiteral encodes a JSON value as a GraphQL input literal.
+func graphQLLiteral(v any) (string, error) {
+	switch val := v.(type) {
+	case nil:
+		return "null", nil
+	case bool:
+		return strconv.FormatBool(val), nil
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64), nil
+	case string:
+		b, err := json.Marshal(val)
+		return string(b), err
+	case GraphQLEnum:
+		if !isGraphQLName(string(val)) || val == "true" || val == "false" || val == "null" {
+			return "", fmt.Errorf("invalid graphql enum value %q", val)
+		}
+
+		return string(val), nil
+	case []any:
+		items := make([]string, 0, len(val))
+
+		for _, item := range val {
+			lit, err := graphQLLiteral(item)
+			if err != nil {
+				return "", err
+			}
+
+			items = append(items, lit)
+		}
+
+		return "[" + strings.Join(items, ", ") + "]", nil
+	case map[string]any:
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+
+		sort.Strings(keys)
+
+		fields := make([]string, 0, len(val))
+
+		for _, k := range keys {
+			if !isGraphQLName(k) {
+				return "", fmt.Errorf("invalid graphql argument name %q", k)
+			}
+
+			lit, err := graphQLLiteral(val[k])
+			if err != nil {
+				return "", err
+			}
+
+			fields = append(fields, k+": "+lit)
+		}
+
+		return "{" + strings.Join(fields, ", ") + "}", nil
+	default:
+		// values not coming from a decoded JSON document
+		b, err := json.Marshal(val)
+		if err != nil {
+			return "", err
+		}
+
+		var decoded any
+
+		if errDecode := json.Unmarshal(b, &decoded); errDecode != nil {
+			return "", errDecode
+		}
+
+		return graphQLLiteral(decoded)
+	}
+}
+
+// isGraphQLName reports whether s is a valid GraphQL name, like field, argument and enum value names.
+func isGraphQLName(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for i, c := range s {
+		switch {
+		case c == '_', c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+		case c >= '0' && c <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+
+	return true
+}
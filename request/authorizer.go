@@ -0,0 +1,18 @@
+package request
+
+import "context"
+
+// HeaderMethodARN is added to the requests built from API Gateway Lambda authorizer events with the ARN of the method,
+// or the HTTP API route, being authorized.
+const HeaderMethodARN = "X-Method-Arn"
+
+// MethodARN returns the ARN of the method, or the HTTP API route, being authorized by an API Gateway Lambda authorizer
+// request. The second value is false when the request didn't come from an authorizer event.
+func MethodARN(ctx context.Context) (string, bool) {
+	arn, ok := ctx.Value(methodARNKey).(string)
+	return arn, ok
+}
+
+func withMethodARN(ctx context.Context, arn string) context.Context {
+	return context.WithValue(ctx, methodARNKey, arn)
+}
@@ -0,0 +1,52 @@
+package request
+
+// BedrockAgentEvent is the event sent by a Bedrock agent to the Lambda function of an action group defined with an
+// OpenAPI schema.
+type BedrockAgentEvent struct {
+	MessageVersion          string                   `json:"messageVersion"`
+	Agent                   BedrockAgent             `json:"agent"`
+	InputText               string                   `json:"inputText"`
+	SessionID               string                   `json:"sessionId"`
+	ActionGroup             string                   `json:"actionGroup"`
+	APIPath                 string                   `json:"apiPath"`
+	HTTPMethod              string                   `json:"httpMethod"`
+	Parameters              []BedrockAgentParameter  `json:"parameters,omitempty"`
+	RequestBody             *BedrockAgentRequestBody `json:"requestBody,omitempty"`
+	SessionAttributes       map[string]string        `json:"sessionAttributes,omitempty"`
+	PromptSessionAttributes map[string]string        `json:"promptSessionAttributes,omitempty"`
+}
+
+// BedrockAgent identifies the agent that invoked the action group.
+type BedrockAgent struct {
+	Name    string `json:"name"`
+	ID      string `json:"id"`
+	Alias   string `json:"alias"`
+	Version string `json:"version"`
+}
+
+// BedrockAgentParameter is a path, query or body parameter elicited by the agent.
+type BedrockAgentParameter struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// BedrockAgentRequestBody is the request body, keyed by its content type.
+type BedrockAgentRequestBody struct {
+	Content map[string]BedrockAgentContent `json:"content"`
+}
+
+// BedrockAgentContent holds the body properties of a content type.
+type BedrockAgentContent struct {
+	Properties []BedrockAgentParameter `json:"properties"`
+}
+
+// BedrockAgentContext is the value added to the request context under ContextKey for Bedrock agent events.
+type BedrockAgentContext struct {
+	Agent                   BedrockAgent
+	InputText               string
+	SessionID               string
+	ActionGroup             string
+	SessionAttributes       map[string]string
+	PromptSessionAttributes map[string]string
+}
@@ -0,0 +1,124 @@
+package request
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// CloudFrontEvent is the event sent to Lambda@Edge functions. It always carries a single record.
+type CloudFrontEvent struct {
+	Records []CloudFrontRecord `json:"Records"`
+}
+
+// CloudFrontRecord is a Lambda@Edge event record.
+type CloudFrontRecord struct {
+	CF CloudFrontRecordData `json:"cf"`
+}
+
+// CloudFrontRecordData contains the distribution config and the request of a Lambda@Edge event record.
+type CloudFrontRecordData struct {
+	Config  CloudFrontConfig  `json:"config"`
+	Request CloudFrontRequest `json:"request"`
+}
+
+// CloudFrontConfig identifies the distribution and the trigger of a Lambda@Edge event.
+type CloudFrontConfig struct {
+	DistributionDomainName string `json:"distributionDomainName"`
+	DistributionID         string `json:"distributionId"`
+	EventType              string `json:"eventType"`
+	RequestID              string `json:"requestId"`
+}
+
+// CloudFrontRequest is the viewer or origin request of a Lambda@Edge event. It's also returned to CloudFront to
+// forward the, maybe modified, request to the origin.
+type CloudFrontRequest struct {
+	ClientIP    string            `json:"clientIp"`
+	Method      string            `json:"method"`
+	URI         string            `json:"uri"`
+	Querystring string            `json:"querystring"`
+	Headers     CloudFrontHeaders `json:"headers"`
+	Body        *CloudFrontBody   `json:"body,omitempty"`
+	Origin      json.RawMessage   `json:"origin,omitempty"`
+}
+
+// CloudFrontBody is the request body, only sent when the trigger is configured to include it.
+type CloudFrontBody struct {
+	InputTruncated bool   `json:"inputTruncated"`
+	Action         string `json:"action"`
+	Encoding       string `json:"encoding"`
+	Data           string `json:"data"`
+}
+
+// CloudFrontHeaders are the CloudFront headers, keyed by the lowercased header name.
+type CloudFrontHeaders map[string][]CloudFrontHeader
+
+// CloudFrontHeader is a single CloudFront header value with its original casing.
+type CloudFrontHeader struct {
+	Key   string `json:"key,omitempty"`
+	Value string `json:"value"`
+}
+
+// cloudFrontForward holds the request a handler decided to forward to the origin.
+type cloudFrontForward struct {
+	req *http.Request
+}
+
+// ForwardToOrigin makes a Lambda@Edge request continue to the origin with the URI, querystring and headers of r,
+// instead of answering with the handler response. It returns false when r doesn't come from a Lambda@Edge event.
+func ForwardToOrigin(r *http.Request) bool {
+	fwd, ok := r.Context().Value(cloudFrontForwardKey).(*cloudFrontForward)
+	if !ok {
+		return false
+	}
+
+	fwd.req = r
+
+	return true
+}
+
+// CloudFrontForwarded returns the request to send back to CloudFront when the handler called ForwardToOrigin. The
+// ctx must be the one of the request built by NewCloudFront.
+func CloudFrontForwarded(ctx context.Context, evt CloudFrontEvent) (CloudFrontRequest, bool) {
+	fwd, ok := ctx.Value(cloudFrontForwardKey).(*cloudFrontForward)
+	if !ok || fwd.req == nil || len(evt.Records) == 0 {
+		return CloudFrontRequest{}, false
+	}
+
+	orig := evt.Records[0].CF.Request
+	out := orig
+
+	out.URI = fwd.req.URL.EscapedPath()
+	out.Querystring = fwd.req.URL.RawQuery
+	out.Headers = make(CloudFrontHeaders, len(fwd.req.Header))
+
+	for k, values := range fwd.req.Header {
+		name := strings.ToLower(k)
+
+		// skip the headers added while building the request that weren't sent by CloudFront
+		if _, sent := orig.Headers[name]; !sent && isSyntheticHeader(name) {
+			continue
+		}
+
+		key := k
+		if sent := orig.Headers[name]; len(sent) > 0 && sent[0].Key != "" {
+			key = sent[0].Key
+		}
+
+		for _, v := range values {
+			out.Headers[name] = append(out.Headers[name], CloudFrontHeader{Key: key, Value: v})
+		}
+	}
+
+	return out, true
+}
+
+func isSyntheticHeader(name string) bool {
+	switch name {
+	case "x-request-id", "x-stage", "content-length":
+		return true
+	default:
+		return false
+	}
+}
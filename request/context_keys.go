@@ -1,7 +1,73 @@
 package request
 
+import (
+	"context"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
 // ctxKey is the type used for any items added to the request context.
 type ctxKey string
 
 // ContextKey is the key for the api gateway proxy `RequestContext`.
 const ContextKey ctxKey = "gateway:requestContext"
+
+// ConnectionManagerKey is the key for the WebSocket connection manager added to the context of WebSocket requests.
+const ConnectionManagerKey ctxKey = "gateway:connectionManager"
+
+// cloudFrontForwardKey is the key for the holder of the request forwarded to the origin by ForwardToOrigin.
+const cloudFrontForwardKey ctxKey = "gateway:cloudFrontForward"
+
+// methodARNKey is the key for the method ARN of API Gateway Lambda authorizer requests.
+const methodARNKey ctxKey = "gateway:methodArn"
+
+// taskTokenKey is the key for the token of Step Functions tasks invoked with the `.waitForTaskToken` pattern.
+const taskTokenKey ctxKey = "gateway:taskToken"
+
+// Headers added to the requests built from API Gateway WebSocket events.
+const (
+	HeaderConnectionID = "X-Connection-Id"
+	HeaderRouteKey     = "X-Route-Key"
+	HeaderEventType    = "X-Event-Type"
+)
+
+// Headers added to the requests built from the records of queue and stream events.
+const (
+	HeaderMessageID      = "X-Message-Id"
+	HeaderSourceARN      = "X-Source-Arn"
+	HeaderEventName      = "X-Event-Name"
+	HeaderPartitionKey   = "X-Partition-Key"
+	HeaderSequenceNumber = "X-Sequence-Number"
+	HeaderSubject        = "X-Subject"
+	HeaderTopicARN       = "X-Topic-Arn"
+	HeaderBucket         = "X-Bucket"
+	HeaderObjectKey      = "X-Object-Key"
+	HeaderTopic          = "X-Topic"
+	HeaderPartition      = "X-Partition"
+	HeaderOffset         = "X-Offset"
+	HeaderRecordKey      = "X-Record-Key"
+	HeaderRecordID       = "X-Record-Id"
+)
+
+// HeaderPhysicalResourceID is added to the requests built from CloudFormation custom resource events with the physical
+// ID of the resource, empty on Create. Handlers set it in the response to choose the ID of a new resource.
+const HeaderPhysicalResourceID = "X-Physical-Resource-Id"
+
+// Headers added to the requests built from EventBridge events.
+const (
+	HeaderEventID     = "X-Event-Id"
+	HeaderEventTime   = "X-Event-Time"
+	HeaderEventSource = "X-Event-Source"
+	HeaderDetailType  = "X-Detail-Type"
+)
+
+// FunctionURLIAM returns the IAM identity of the caller of a Lambda Function URL configured with the AWS_IAM auth
+// type. The second value is false when the request didn't come from a Function URL or wasn't signed with IAM.
+func FunctionURLIAM(ctx context.Context) (*events.LambdaFunctionURLRequestContextAuthorizerIAMDescription, bool) {
+	reqCtx, ok := ctx.Value(ContextKey).(events.LambdaFunctionURLRequestContext)
+	if !ok || reqCtx.Authorizer == nil || reqCtx.Authorizer.IAM == nil {
+		return nil, false
+	}
+
+	return reqCtx.Authorizer.IAM, true
+}
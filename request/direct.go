@@ -0,0 +1,69 @@
+package request
+
+import (
+	"encoding/base64"
+	"io"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+// DirectInvokeEvent is the lamway envelope for functions invoked directly with the Lambda Invoke API, so service to
+// service calls don't need to mimic API Gateway events:
+//
+//	{
+//	  "method": "POST",
+//	  "path": "/pets?notify=true",
+//	  "headers": {"Content-Type": "application/json"},
+//	  "body": "{\"name\":\"luna\"}",
+//	  "isBase64": false
+//	}
+//
+// The path can include the query string, and binary bodies are sent base64 encoded with isBase64 set. The gateway
+// answers with a response.DirectInvokeResponse.
+type DirectInvokeEvent struct {
+	Method   string            `json:"method"`
+	Path     string            `json:"path"`
+	Headers  map[string]string `json:"headers,omitempty"`
+	Body     string            `json:"body,omitempty"`
+	IsBase64 bool              `json:"isBase64,omitempty"`
+}
+
+// NewDirectInvokeEvent builds the envelope of r for callers invoking a lamway function directly. The body is read and
+// base64 encoded when it isn't valid UTF-8, and repeated headers are joined with a comma.
+func NewDirectInvokeEvent(r *http.Request) (DirectInvokeEvent, error) {
+	evt := DirectInvokeEvent{
+		Method: r.Method,
+		Path:   r.URL.RequestURI(),
+	}
+
+	if evt.Method == "" {
+		evt.Method = http.MethodGet
+	}
+
+	if len(r.Header) > 0 {
+		evt.Headers = make(map[string]string, len(r.Header))
+
+		for k, values := range r.Header {
+			evt.Headers[k] = strings.Join(values, ",")
+		}
+	}
+
+	if r.Body == nil || r.Body == http.NoBody {
+		return evt, nil
+	}
+
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		return DirectInvokeEvent{}, err
+	}
+
+	if utf8.Valid(b) {
+		evt.Body = string(b)
+	} else {
+		evt.Body = base64.StdEncoding.EncodeToString(b)
+		evt.IsBase64 = true
+	}
+
+	return evt, nil
+}
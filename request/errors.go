@@ -3,7 +3,10 @@ package request
 import "errors"
 
 var (
-	ErrParsingPathFailed   = errors.New("gateway[request]: parsing path failed")
-	ErrDecodingBase64Body  = errors.New("gateway[request]: decoding base64 body")
-	ErrFailToCreateRequest = errors.New("gateway[request]: fail to create request")
+	ErrParsingPathFailed       = errors.New("gateway[request]: parsing path failed")
+	ErrDecodingBase64Body      = errors.New("gateway[request]: decoding base64 body")
+	ErrFailToCreateRequest     = errors.New("gateway[request]: fail to create request")
+	ErrEventWithoutRecords     = errors.New("gateway[request]: event without records")
+	ErrUnsupportedEvent        = errors.New("gateway[request]: unsupported event")
+	ErrUnsupportedAppSyncField = errors.New("gateway[request]: appsync field can't be queried from the schema root")
 )
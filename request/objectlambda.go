@@ -0,0 +1,18 @@
+package request
+
+import (
+	"context"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// ObjectLambdaInputURL returns the pre-signed URL of the original object of an S3 Object Lambda GetObject request. The
+// second value is false when the request didn't come from S3 Object Lambda.
+func ObjectLambdaInputURL(ctx context.Context) (string, bool) {
+	evt, ok := ctx.Value(ContextKey).(events.S3ObjectLambdaEvent)
+	if !ok || evt.GetObjectContext == nil {
+		return "", false
+	}
+
+	return evt.GetObjectContext.InputS3URL, true
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/aws/aws-lambda-go/cfn"
 	"github.com/aws/aws-lambda-go/events"
 )
 
@@ -20,3 +21,220 @@ func NewV2(ctx context.Context, evt events.APIGatewayV2HTTPRequest) (*http.Reque
 	ri := newAPIGatewayV2RequestInfo(evt)
 	return ri.toRequest(ctx)
 }
+
+func NewFunctionURL(ctx context.Context, evt events.LambdaFunctionURLRequest) (*http.Request, error) {
+	ri := newFunctionURLRequestInfo(evt)
+	return ri.toRequest(ctx)
+}
+
+// NewAuthorizer builds the request authorized by an API Gateway REQUEST Lambda authorizer event. The method ARN is
+// available with MethodARN and in the X-Method-Arn header.
+func NewAuthorizer(ctx context.Context, evt events.APIGatewayCustomAuthorizerRequestTypeRequest) (*http.Request, error) {
+	ri, err := newAuthorizerRequestInfo(evt)
+	if err != nil {
+		return nil, err
+	}
+
+	return ri.toRequest(withMethodARN(ctx, evt.MethodArn))
+}
+
+// NewTokenAuthorizer builds the request authorized by an API Gateway TOKEN Lambda authorizer event. The token is sent
+// in the Authorization header, and the method and path are taken from the method ARN, which is available with
+// MethodARN and in the X-Method-Arn header.
+func NewTokenAuthorizer(ctx context.Context, evt events.APIGatewayCustomAuthorizerRequest) (*http.Request, error) {
+	ri := newTokenAuthorizerRequestInfo(evt)
+	return ri.toRequest(withMethodARN(ctx, evt.MethodArn))
+}
+
+// NewAuthorizerV2 builds the request authorized by an API Gateway HTTP API Lambda authorizer event using the 2.0
+// payload format. The route ARN is available with MethodARN and in the X-Method-Arn header.
+func NewAuthorizerV2(ctx context.Context, evt events.APIGatewayV2CustomAuthorizerV2Request) (*http.Request, error) {
+	ri := newAuthorizerV2RequestInfo(evt)
+	return ri.toRequest(withMethodARN(ctx, evt.RouteArn))
+}
+
+func NewALB(ctx context.Context, evt events.ALBTargetGroupRequest) (*http.Request, error) {
+	ri, err := newALBRequestInfo(evt)
+	if err != nil {
+		return nil, err
+	}
+
+	return ri.toRequest(ctx)
+}
+
+func NewVPCLatticeV1(ctx context.Context, evt VPCLatticeEventV1) (*http.Request, error) {
+	ri, err := newVPCLatticeV1RequestInfo(evt)
+	if err != nil {
+		return nil, err
+	}
+
+	return ri.toRequest(ctx)
+}
+
+func NewVPCLatticeV2(ctx context.Context, evt VPCLatticeEventV2) (*http.Request, error) {
+	ri := newVPCLatticeV2RequestInfo(evt)
+	return ri.toRequest(ctx)
+}
+
+func NewWebsocket(ctx context.Context, evt events.APIGatewayWebsocketProxyRequest) (*http.Request, error) {
+	ri, err := newWebsocketRequestInfo(evt)
+	if err != nil {
+		return nil, err
+	}
+
+	return ri.toRequest(ctx)
+}
+
+// NewCloudFront builds the request of a Lambda@Edge event. Handlers can call ForwardToOrigin to send the request to
+// the origin instead of answering it.
+func NewCloudFront(ctx context.Context, evt CloudFrontEvent) (*http.Request, error) {
+	ri, err := newCloudFrontRequestInfo(evt)
+	if err != nil {
+		return nil, err
+	}
+
+	return ri.toRequest(context.WithValue(ctx, cloudFrontForwardKey, &cloudFrontForward{}))
+}
+
+func NewBedrockAgent(ctx context.Context, evt BedrockAgentEvent) (*http.Request, error) {
+	ri, err := newBedrockAgentRequestInfo(evt)
+	if err != nil {
+		return nil, err
+	}
+
+	return ri.toRequest(ctx)
+}
+
+// NewAppSync builds a GraphQL POST request to path resolving the field of an AppSync direct Lambda resolver event.
+// The query is built with buildQuery, or with AppSyncQuery when nil.
+func NewAppSync(ctx context.Context, evt AppSyncResolverEvent, path string, buildQuery AppSyncQueryFunc) (*http.Request, error) {
+	ri, err := newAppSyncRequestInfo(evt, path, buildQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	return ri.toRequest(ctx)
+}
+
+// NewSQS builds a POST request to path from an SQS message. The message attributes are sent as headers and the
+// message is available in the request context under ContextKey.
+func NewSQS(ctx context.Context, msg events.SQSMessage, path string) (*http.Request, error) {
+	ri := newSQSRequestInfo(msg, path)
+	return ri.toRequest(ctx)
+}
+
+// NewEventBridge builds a POST request to path with the detail of an EventBridge event as the JSON body. The event is
+// available in the request context under ContextKey.
+func NewEventBridge(ctx context.Context, evt events.EventBridgeEvent, path string) (*http.Request, error) {
+	ri := newEventBridgeRequestInfo(evt, path)
+	return ri.toRequest(ctx)
+}
+
+// NewKinesis builds a POST request to path with the data of a Kinesis record as the body. The record is available in
+// the request context under ContextKey.
+func NewKinesis(ctx context.Context, record events.KinesisEventRecord, path string) (*http.Request, error) {
+	ri := newKinesisRequestInfo(record, path)
+	return ri.toRequest(ctx)
+}
+
+// NewDynamoDB builds a POST request to path from a DynamoDB stream record. The body is a JSON document with the
+// `keys`, `newImage` and `oldImage` of the item, and the record is available in the request context under ContextKey.
+func NewDynamoDB(ctx context.Context, record events.DynamoDBEventRecord, path string) (*http.Request, error) {
+	ri, err := newDynamoDBRequestInfo(record, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ri.toRequest(ctx)
+}
+
+// NewSNS builds a POST request to path with the message of an SNS record as the body. The subject and the message
+// attributes are sent as headers, and the record is available in the request context under ContextKey.
+func NewSNS(ctx context.Context, record events.SNSEventRecord, path string) (*http.Request, error) {
+	ri := newSNSRequestInfo(record, path)
+	return ri.toRequest(ctx)
+}
+
+// NewS3 builds a POST request to path with an S3 notification record as the JSON body. The record is available in the
+// request context under ContextKey.
+func NewS3(ctx context.Context, record events.S3EventRecord, path string) (*http.Request, error) {
+	ri, err := newS3RequestInfo(record, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ri.toRequest(ctx)
+}
+
+// S3ObjectKey returns the decoded object key of an S3 notification record.
+func S3ObjectKey(record events.S3EventRecord) string {
+	if record.S3.Object.URLDecodedKey != "" {
+		return record.S3.Object.URLDecodedKey
+	}
+
+	return albUnescape(record.S3.Object.Key)
+}
+
+// NewKafka builds a POST request to path with the decoded value of a Kafka record as the body. The record headers are
+// sent as headers, and the record is available in the request context under ContextKey.
+func NewKafka(ctx context.Context, record events.KafkaRecord, path string) (*http.Request, error) {
+	ri, err := newKafkaRequestInfo(record, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ri.toRequest(ctx)
+}
+
+// NewFirehose builds a POST request to path with the data of a Kinesis Data Firehose record as the body. The record is
+// available in the request context under ContextKey.
+func NewFirehose(ctx context.Context, record events.KinesisFirehoseEventRecord, path string) (*http.Request, error) {
+	ri := newFirehoseRequestInfo(record, path)
+	return ri.toRequest(ctx)
+}
+
+// NewCloudFormation builds the request of a CloudFormation custom resource event, a POST, PUT or DELETE to path for
+// the Create, Update and Delete request types, with the resource properties as the JSON body. The event is available
+// in the request context under ContextKey.
+func NewCloudFormation(ctx context.Context, evt cfn.Event, path string) (*http.Request, error) {
+	ri, err := newCloudFormationRequestInfo(evt, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ri.toRequest(ctx)
+}
+
+// NewStepFunctions builds a POST request to path with the input of a Step Functions task as the JSON body. The input
+// is available in the request context under ContextKey, and the taskToken, when not empty, with TaskToken.
+func NewStepFunctions(ctx context.Context, input StepFunctionsTask, path, taskToken string) (*http.Request, error) {
+	if taskToken != "" {
+		ctx = context.WithValue(ctx, taskTokenKey, taskToken)
+	}
+
+	ri := newStepFunctionsRequestInfo(input, path)
+
+	return ri.toRequest(ctx)
+}
+
+// NewS3ObjectLambda builds the GET request of the original object key of an S3 Object Lambda GetObject event. The
+// event is available in the request context under ContextKey, and the url of the original object with
+// ObjectLambdaInputURL.
+func NewS3ObjectLambda(ctx context.Context, evt events.S3ObjectLambdaEvent) (*http.Request, error) {
+	ri, err := newS3ObjectLambdaRequestInfo(evt)
+	if err != nil {
+		return nil, err
+	}
+
+	return ri.toRequest(ctx)
+}
+
+// NewDirectInvoke builds the request of a DirectInvokeEvent.
+func NewDirectInvoke(ctx context.Context, evt DirectInvokeEvent) (*http.Request, error) {
+	ri, err := newDirectInvokeRequestInfo(evt)
+	if err != nil {
+		return nil, err
+	}
+
+	return ri.toRequest(ctx)
+}
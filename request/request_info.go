@@ -3,13 +3,16 @@ package request
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/aws/aws-lambda-go/cfn"
 	"github.com/aws/aws-lambda-go/events"
 )
 
@@ -49,6 +52,45 @@ func newAPIGatewayV2RequestInfo(evt events.APIGatewayV2HTTPRequest) requestInfo
 	}
 }
 
+func newAuthorizerV2RequestInfo(evt events.APIGatewayV2CustomAuthorizerV2Request) requestInfo {
+	// the authorizer receives the same request data as the HTTP API integration, without the body
+	ri := newAPIGatewayV2RequestInfo(events.APIGatewayV2HTTPRequest{
+		RouteKey:              evt.RouteKey,
+		RawPath:               evt.RawPath,
+		RawQueryString:        evt.RawQueryString,
+		Cookies:               evt.Cookies,
+		Headers:               evt.Headers,
+		QueryStringParameters: evt.QueryStringParameters,
+		PathParameters:        evt.PathParameters,
+		StageVariables:        evt.StageVariables,
+		RequestContext:        evt.RequestContext,
+	})
+
+	ri.headers = map[string]string{HeaderMethodARN: evt.RouteArn}
+
+	return ri
+}
+
+func newFunctionURLRequestInfo(evt events.LambdaFunctionURLRequest) requestInfo {
+	multiHeader := make(map[string][]string)
+	for k, values := range evt.Headers {
+		multiHeader[k] = strings.Split(values, ",")
+	}
+
+	return requestInfo{
+		path:        evt.RawPath,
+		queryString: evt.RawQueryString,
+		body:        evt.Body,
+		isBase64:    evt.IsBase64Encoded,
+		method:      evt.RequestContext.HTTP.Method,
+		context:     evt.RequestContext,
+		sourceIP:    evt.RequestContext.HTTP.SourceIP,
+		multiHeader: multiHeader,
+		cookies:     evt.Cookies,
+		requestID:   evt.RequestContext.RequestID,
+	}
+}
+
 func newAPIGatewayV1RequestInfo(evt events.APIGatewayProxyRequest) (requestInfo, error) {
 	u, err := url.Parse(evt.Path)
 	if err != nil {
@@ -80,6 +122,590 @@ func newAPIGatewayV1RequestInfo(evt events.APIGatewayProxyRequest) (requestInfo,
 	}, nil
 }
 
+func newAuthorizerRequestInfo(evt events.APIGatewayCustomAuthorizerRequestTypeRequest) (requestInfo, error) {
+	// the authorizer receives the same request data as the proxy integration, without the body
+	ri, err := newAPIGatewayV1RequestInfo(events.APIGatewayProxyRequest{
+		Resource:                        evt.Resource,
+		Path:                            evt.Path,
+		HTTPMethod:                      evt.HTTPMethod,
+		Headers:                         evt.Headers,
+		MultiValueHeaders:               evt.MultiValueHeaders,
+		QueryStringParameters:           evt.QueryStringParameters,
+		MultiValueQueryStringParameters: evt.MultiValueQueryStringParameters,
+		PathParameters:                  evt.PathParameters,
+		StageVariables:                  evt.StageVariables,
+	})
+	if err != nil {
+		return requestInfo{}, err
+	}
+
+	headers := make(map[string]string, len(evt.Headers)+1)
+	for k, v := range evt.Headers {
+		headers[k] = v
+	}
+
+	headers[HeaderMethodARN] = evt.MethodArn
+
+	ri.headers = headers
+	ri.context = evt.RequestContext
+	ri.sourceIP = evt.RequestContext.Identity.SourceIP
+	ri.requestID = evt.RequestContext.RequestID
+	ri.stage = evt.RequestContext.Stage
+
+	return ri, nil
+}
+
+func newTokenAuthorizerRequestInfo(evt events.APIGatewayCustomAuthorizerRequest) requestInfo {
+	// TOKEN authorizers only receive the token and the method ARN, which has the form
+	// arn:aws:execute-api:{region}:{account-id}:{api-id}/{stage}/{method}/{resource-path}
+	method, path, stage := http.MethodGet, "/", ""
+
+	if _, resource, ok := strings.Cut(evt.MethodArn, "/"); ok {
+		parts := strings.SplitN(resource, "/", 3)
+		stage = parts[0]
+
+		if len(parts) > 1 && parts[1] != "" {
+			method = parts[1]
+		}
+
+		if len(parts) > 2 {
+			path = "/" + parts[2]
+		}
+	}
+
+	return requestInfo{
+		path:   path,
+		method: method,
+		headers: map[string]string{
+			"Authorization": evt.AuthorizationToken,
+			HeaderMethodARN: evt.MethodArn,
+		},
+		context: evt,
+		stage:   stage,
+	}
+}
+
+func newALBRequestInfo(evt events.ALBTargetGroupRequest) (requestInfo, error) {
+	u, err := url.Parse(evt.Path)
+	if err != nil {
+		return requestInfo{}, errors.Join(err, ErrParsingPathFailed)
+	}
+
+	// querystring, ALB forwards the parameters exactly as the client sent them so they need to be unescaped
+	q := u.Query()
+
+	for k, v := range evt.QueryStringParameters {
+		q.Set(albUnescape(k), albUnescape(v))
+	}
+
+	for k, values := range evt.MultiValueQueryStringParameters {
+		unescaped := make([]string, 0, len(values))
+
+		for _, v := range values {
+			unescaped = append(unescaped, albUnescape(v))
+		}
+
+		q[albUnescape(k)] = unescaped
+	}
+
+	return requestInfo{
+		path:        evt.Path,
+		queryString: q.Encode(),
+		body:        evt.Body,
+		isBase64:    evt.IsBase64Encoded,
+		method:      evt.HTTPMethod,
+		context:     evt.RequestContext,
+		sourceIP:    forwardedForIP(evt.Headers, evt.MultiValueHeaders),
+		headers:     evt.Headers,
+		multiHeader: evt.MultiValueHeaders,
+	}, nil
+}
+
+func newVPCLatticeV1RequestInfo(evt VPCLatticeEventV1) (requestInfo, error) {
+	u, err := url.Parse(evt.RawPath)
+	if err != nil {
+		return requestInfo{}, errors.Join(err, ErrParsingPathFailed)
+	}
+
+	// querystring, raw_path already carries it but query_string_parameters is the decoded version
+	q := u.Query()
+
+	for k, v := range evt.QueryStringParameters {
+		q.Set(k, v)
+	}
+
+	return requestInfo{
+		path:        u.EscapedPath(),
+		queryString: q.Encode(),
+		body:        evt.Body,
+		isBase64:    evt.IsBase64Encoded,
+		method:      evt.Method,
+		context:     newVPCLatticeV1RequestContext(evt.Headers),
+		sourceIP:    forwardedForIP(evt.Headers, nil),
+		headers:     evt.Headers,
+	}, nil
+}
+
+func newVPCLatticeV2RequestInfo(evt VPCLatticeEventV2) requestInfo {
+	q := url.Values{}
+
+	for k, values := range evt.QueryStringParameters {
+		q[k] = values
+	}
+
+	return requestInfo{
+		path:        evt.Path,
+		queryString: q.Encode(),
+		body:        evt.Body,
+		isBase64:    evt.IsBase64Encoded,
+		method:      evt.Method,
+		context:     evt.RequestContext,
+		sourceIP:    forwardedForIP(nil, evt.Headers),
+		multiHeader: evt.Headers,
+	}
+}
+
+func newWebsocketRequestInfo(evt events.APIGatewayWebsocketProxyRequest) (requestInfo, error) {
+	// every route is served as a POST to the route key, e.g. POST /$connect or POST /sendMessage
+	u, err := url.Parse("/" + strings.TrimPrefix(evt.RequestContext.RouteKey, "/"))
+	if err != nil {
+		return requestInfo{}, errors.Join(err, ErrParsingPathFailed)
+	}
+
+	q := u.Query()
+	for k, v := range evt.QueryStringParameters {
+		q.Set(k, v)
+	}
+
+	for k, values := range evt.MultiValueQueryStringParameters {
+		q[k] = values
+	}
+
+	headers := make(map[string]string, len(evt.Headers)+3)
+	for k, v := range evt.Headers {
+		headers[k] = v
+	}
+
+	headers[HeaderConnectionID] = evt.RequestContext.ConnectionID
+	headers[HeaderRouteKey] = evt.RequestContext.RouteKey
+	headers[HeaderEventType] = evt.RequestContext.EventType
+
+	return requestInfo{
+		path:        u.EscapedPath(),
+		queryString: q.Encode(),
+		body:        evt.Body,
+		isBase64:    evt.IsBase64Encoded,
+		method:      http.MethodPost,
+		context:     evt.RequestContext,
+		sourceIP:    evt.RequestContext.Identity.SourceIP,
+		headers:     headers,
+		multiHeader: evt.MultiValueHeaders,
+		requestID:   evt.RequestContext.RequestID,
+		stage:       evt.RequestContext.Stage,
+	}, nil
+}
+
+func newCloudFrontRequestInfo(evt CloudFrontEvent) (requestInfo, error) {
+	if len(evt.Records) == 0 {
+		return requestInfo{}, ErrEventWithoutRecords
+	}
+
+	cf := evt.Records[0].CF
+
+	multiHeader := make(map[string][]string, len(cf.Request.Headers))
+	for name, values := range cf.Request.Headers {
+		for _, h := range values {
+			multiHeader[name] = append(multiHeader[name], h.Value)
+		}
+	}
+
+	ri := requestInfo{
+		path:        cf.Request.URI,
+		queryString: cf.Request.Querystring,
+		method:      cf.Request.Method,
+		context:     cf.Config,
+		sourceIP:    cf.Request.ClientIP,
+		multiHeader: multiHeader,
+		requestID:   cf.Config.RequestID,
+	}
+
+	if cf.Request.Body != nil {
+		ri.body = cf.Request.Body.Data
+		ri.isBase64 = cf.Request.Body.Encoding == "base64"
+	}
+
+	return ri, nil
+}
+
+func newBedrockAgentRequestInfo(evt BedrockAgentEvent) (requestInfo, error) {
+	// parameters matching an {name} placeholder of the api path are path parameters, the rest go to the querystring
+	path := evt.APIPath
+	q := url.Values{}
+
+	for _, p := range evt.Parameters {
+		placeholder := "{" + p.Name + "}"
+
+		if strings.Contains(path, placeholder) {
+			path = strings.ReplaceAll(path, placeholder, url.PathEscape(p.Value))
+			continue
+		}
+
+		q.Add(p.Name, p.Value)
+	}
+
+	headers := make(map[string]string)
+
+	body := ""
+
+	if evt.RequestBody != nil {
+		for contentType, content := range evt.RequestBody.Content {
+			props := make(map[string]any, len(content.Properties))
+			for _, p := range content.Properties {
+				props[p.Name] = bedrockValue(p)
+			}
+
+			b, err := json.Marshal(props)
+			if err != nil {
+				return requestInfo{}, errors.Join(err, ErrFailToCreateRequest)
+			}
+
+			headers["Content-Type"] = contentType
+			body = string(b)
+
+			break
+		}
+	}
+
+	return requestInfo{
+		path:        path,
+		queryString: q.Encode(),
+		body:        body,
+		method:      evt.HTTPMethod,
+		headers:     headers,
+		requestID:   evt.SessionID,
+		context: BedrockAgentContext{
+			Agent:                   evt.Agent,
+			InputText:               evt.InputText,
+			SessionID:               evt.SessionID,
+			ActionGroup:             evt.ActionGroup,
+			SessionAttributes:       evt.SessionAttributes,
+			PromptSessionAttributes: evt.PromptSessionAttributes,
+		},
+	}, nil
+}
+
+func newAppSyncRequestInfo(evt AppSyncResolverEvent, path string, buildQuery AppSyncQueryFunc) (requestInfo, error) {
+	if buildQuery == nil {
+		buildQuery = AppSyncQuery
+	}
+
+	query, err := buildQuery(evt)
+	if err != nil {
+		return requestInfo{}, errors.Join(err, ErrFailToCreateRequest)
+	}
+
+	// arguments are inlined in the query, so the operation variables aren't needed
+	body, errMarshal := json.Marshal(map[string]string{"query": query})
+	if errMarshal != nil {
+		return requestInfo{}, errors.Join(errMarshal, ErrFailToCreateRequest)
+	}
+
+	headers := make(map[string]string, len(evt.Request.Headers)+1)
+	for k, v := range evt.Request.Headers {
+		headers[k] = v
+	}
+
+	// the original request may have been a GET or a websocket message, the resolver request is always a JSON POST
+	headers["Content-Type"] = "application/json"
+	delete(headers, "content-length")
+
+	sourceIP := forwardedForIP(evt.Request.Headers, nil)
+	if evt.Identity != nil && len(evt.Identity.SourceIP) > 0 {
+		sourceIP = evt.Identity.SourceIP[0]
+	}
+
+	return requestInfo{
+		path:     path,
+		body:     string(body),
+		method:   http.MethodPost,
+		headers:  headers,
+		sourceIP: sourceIP,
+		context: AppSyncRequestContext{
+			Identity: evt.Identity,
+			Source:   evt.Source,
+			Prev:     evt.Prev,
+			Info:     evt.Info,
+			Stash:    evt.Stash,
+		},
+	}, nil
+}
+
+func newSQSRequestInfo(msg events.SQSMessage, path string) requestInfo {
+	headers := make(map[string]string, len(msg.MessageAttributes)+2)
+
+	for name, attr := range msg.MessageAttributes {
+		switch {
+		case attr.StringValue != nil:
+			headers[name] = *attr.StringValue
+		case attr.BinaryValue != nil:
+			headers[name] = base64.StdEncoding.EncodeToString(attr.BinaryValue)
+		}
+	}
+
+	headers[HeaderMessageID] = msg.MessageId
+	headers[HeaderSourceARN] = msg.EventSourceARN
+
+	return requestInfo{
+		path:      path,
+		body:      msg.Body,
+		method:    http.MethodPost,
+		context:   msg,
+		headers:   headers,
+		requestID: msg.MessageId,
+	}
+}
+
+func newEventBridgeRequestInfo(evt events.EventBridgeEvent, path string) requestInfo {
+	return requestInfo{
+		path:   path,
+		body:   string(evt.Detail),
+		method: http.MethodPost,
+		headers: map[string]string{
+			"Content-Type":    "application/json",
+			HeaderEventID:     evt.ID,
+			HeaderEventTime:   evt.Time.Format(time.RFC3339),
+			HeaderEventSource: evt.Source,
+			HeaderDetailType:  evt.DetailType,
+		},
+		context:   evt,
+		requestID: evt.ID,
+	}
+}
+
+func newKinesisRequestInfo(record events.KinesisEventRecord, path string) requestInfo {
+	return requestInfo{
+		path:   path,
+		body:   string(record.Kinesis.Data),
+		method: http.MethodPost,
+		headers: map[string]string{
+			HeaderSourceARN:      record.EventSourceArn,
+			HeaderPartitionKey:   record.Kinesis.PartitionKey,
+			HeaderSequenceNumber: record.Kinesis.SequenceNumber,
+		},
+		context:   record,
+		requestID: record.EventID,
+	}
+}
+
+func newDynamoDBRequestInfo(record events.DynamoDBEventRecord, path string) (requestInfo, error) {
+	// the images are sent as plain JSON documents instead of the typed DynamoDB attribute values
+	body, err := json.Marshal(map[string]any{
+		"keys":     dynamoDBItem(record.Change.Keys),
+		"newImage": dynamoDBItem(record.Change.NewImage),
+		"oldImage": dynamoDBItem(record.Change.OldImage),
+	})
+	if err != nil {
+		return requestInfo{}, errors.Join(err, ErrFailToCreateRequest)
+	}
+
+	return requestInfo{
+		path:   path,
+		body:   string(body),
+		method: http.MethodPost,
+		headers: map[string]string{
+			"Content-Type":       "application/json",
+			HeaderSourceARN:      record.EventSourceArn,
+			HeaderEventName:      record.EventName,
+			HeaderSequenceNumber: record.Change.SequenceNumber,
+		},
+		context:   record,
+		requestID: record.EventID,
+	}, nil
+}
+
+func newSNSRequestInfo(record events.SNSEventRecord, path string) requestInfo {
+	headers := make(map[string]string, len(record.SNS.MessageAttributes)+3)
+
+	// attributes are sent as {"Type": "String", "Value": "..."}
+	for name, attr := range record.SNS.MessageAttributes {
+		if v, ok := attr.(map[string]any); ok {
+			headers[name] = fmt.Sprint(v["Value"])
+		}
+	}
+
+	headers[HeaderMessageID] = record.SNS.MessageID
+	headers[HeaderTopicARN] = record.SNS.TopicArn
+
+	if record.SNS.Subject != "" {
+		headers[HeaderSubject] = record.SNS.Subject
+	}
+
+	return requestInfo{
+		path:      path,
+		body:      record.SNS.Message,
+		method:    http.MethodPost,
+		headers:   headers,
+		context:   record,
+		requestID: record.SNS.MessageID,
+	}
+}
+
+func newS3RequestInfo(record events.S3EventRecord, path string) (requestInfo, error) {
+	body, err := json.Marshal(record)
+	if err != nil {
+		return requestInfo{}, errors.Join(err, ErrFailToCreateRequest)
+	}
+
+	return requestInfo{
+		path:   path,
+		body:   string(body),
+		method: http.MethodPost,
+		headers: map[string]string{
+			"Content-Type":  "application/json",
+			HeaderEventName: record.EventName,
+			HeaderBucket:    record.S3.Bucket.Name,
+			HeaderObjectKey: S3ObjectKey(record),
+		},
+		context:   record,
+		requestID: record.ResponseElements["x-amz-request-id"],
+	}, nil
+}
+
+func newKafkaRequestInfo(record events.KafkaRecord, path string) (requestInfo, error) {
+	key, err := base64.StdEncoding.DecodeString(record.Key)
+	if err != nil {
+		return requestInfo{}, errors.Join(err, ErrDecodingBase64Body)
+	}
+
+	multiHeader := make(map[string][]string, len(record.Headers))
+	for _, h := range record.Headers {
+		for name, value := range h {
+			multiHeader[name] = append(multiHeader[name], string(value))
+		}
+	}
+
+	headers := map[string]string{
+		HeaderTopic:     record.Topic,
+		HeaderPartition: strconv.FormatInt(record.Partition, 10),
+		HeaderOffset:    strconv.FormatInt(record.Offset, 10),
+	}
+
+	if len(key) > 0 {
+		headers[HeaderRecordKey] = string(key)
+	}
+
+	return requestInfo{
+		path:        path,
+		body:        record.Value,
+		isBase64:    true,
+		method:      http.MethodPost,
+		headers:     headers,
+		multiHeader: multiHeader,
+		context:     record,
+	}, nil
+}
+
+func newFirehoseRequestInfo(record events.KinesisFirehoseEventRecord, path string) requestInfo {
+	headers := map[string]string{HeaderRecordID: record.RecordID}
+
+	// only set when the delivery stream reads from a Kinesis data stream
+	if record.KinesisFirehoseRecordMetadata.PartitionKey != "" {
+		headers[HeaderPartitionKey] = record.KinesisFirehoseRecordMetadata.PartitionKey
+		headers[HeaderSequenceNumber] = record.KinesisFirehoseRecordMetadata.SequenceNumber
+	}
+
+	return requestInfo{
+		path:      path,
+		body:      string(record.Data),
+		method:    http.MethodPost,
+		headers:   headers,
+		context:   record,
+		requestID: record.RecordID,
+	}
+}
+
+func newCloudFormationRequestInfo(evt cfn.Event, path string) (requestInfo, error) {
+	method := http.MethodPost
+
+	switch evt.RequestType {
+	case cfn.RequestUpdate:
+		method = http.MethodPut
+	case cfn.RequestDelete:
+		method = http.MethodDelete
+	}
+
+	body, err := json.Marshal(evt.ResourceProperties)
+	if err != nil {
+		return requestInfo{}, errors.Join(err, ErrFailToCreateRequest)
+	}
+
+	return requestInfo{
+		path:   path,
+		body:   string(body),
+		method: method,
+		headers: map[string]string{
+			"Content-Type":           "application/json",
+			HeaderPhysicalResourceID: evt.PhysicalResourceID,
+		},
+		context:   evt,
+		requestID: evt.RequestID,
+	}, nil
+}
+
+func newStepFunctionsRequestInfo(input StepFunctionsTask, path string) requestInfo {
+	return requestInfo{
+		path:    path,
+		body:    string(input),
+		method:  http.MethodPost,
+		headers: map[string]string{"Content-Type": "application/json"},
+		context: input,
+	}
+}
+
+func newS3ObjectLambdaRequestInfo(evt events.S3ObjectLambdaEvent) (requestInfo, error) {
+	if evt.GetObjectContext == nil {
+		return requestInfo{}, fmt.Errorf("%w: only GetObject S3 Object Lambda requests are supported", ErrUnsupportedEvent)
+	}
+
+	// the user request url has the original object key as the path
+	u, err := url.Parse(evt.UserRequest.URL)
+	if err != nil {
+		return requestInfo{}, errors.Join(err, ErrParsingPathFailed)
+	}
+
+	return requestInfo{
+		path:        u.EscapedPath(),
+		queryString: u.RawQuery,
+		method:      http.MethodGet,
+		headers:     evt.UserRequest.Headers,
+		context:     evt,
+		requestID:   evt.XAmzRequestID,
+	}, nil
+}
+
+func newDirectInvokeRequestInfo(evt DirectInvokeEvent) (requestInfo, error) {
+	u, err := url.Parse(evt.Path)
+	if err != nil {
+		return requestInfo{}, errors.Join(err, ErrParsingPathFailed)
+	}
+
+	method := evt.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	return requestInfo{
+		path:        u.EscapedPath(),
+		queryString: u.RawQuery,
+		body:        evt.Body,
+		isBase64:    evt.IsBase64,
+		method:      method,
+		headers:     evt.Headers,
+		context:     evt,
+		requestID:   evt.Headers["X-Request-Id"],
+	}, nil
+}
+
 func (ri requestInfo) toRequest(ctx context.Context) (*http.Request, error) {
 	u, err := url.Parse(ri.path)
 	if err != nil {
@@ -157,3 +783,101 @@ func (ri requestInfo) decodeBody() (string, error) {
 
 	return ri.body, nil
 }
+
+// albUnescape decodes a query string component sent by an ALB, returning it untouched if it is not a valid escape.
+func albUnescape(v string) string {
+	unescaped, err := url.QueryUnescape(v)
+	if err != nil {
+		return v
+	}
+
+	return unescaped
+}
+
+// forwardedForIP returns the client address from the first entry of the X-Forwarded-For header added by load
+// balancers and proxies.
+func forwardedForIP(headers map[string]string, multiHeader map[string][]string) string {
+	forwarded := ""
+
+	for k, v := range headers {
+		if strings.EqualFold(k, "X-Forwarded-For") {
+			forwarded = v
+		}
+	}
+
+	for k, values := range multiHeader {
+		if strings.EqualFold(k, "X-Forwarded-For") && len(values) > 0 {
+			forwarded = values[0]
+		}
+	}
+
+	ip, _, _ := strings.Cut(forwarded, ",")
+
+	return strings.TrimSpace(ip)
+}
+
+// bedrockValue converts the string value elicited by a Bedrock agent to the type declared in the OpenAPI schema,
+// keeping the string when it doesn't match.
+func bedrockValue(p BedrockAgentParameter) any {
+	switch p.Type {
+	case "integer", "number", "boolean", "array", "object":
+		var v any
+
+		if err := json.Unmarshal([]byte(p.Value), &v); err == nil {
+			return v
+		}
+	}
+
+	return p.Value
+}
+
+// dynamoDBItem converts a DynamoDB item to a plain JSON document, nil items stay nil.
+func dynamoDBItem(item map[string]events.DynamoDBAttributeValue) map[string]any {
+	if item == nil {
+		return nil
+	}
+
+	out := make(map[string]any, len(item))
+	for k, av := range item {
+		out[k] = dynamoDBValue(av)
+	}
+
+	return out
+}
+
+// dynamoDBValue converts a DynamoDB attribute value to its plain JSON value. Numbers keep their exact representation
+// and binaries are encoded as base64 strings.
+func dynamoDBValue(av events.DynamoDBAttributeValue) any {
+	switch av.DataType() {
+	case events.DataTypeBinary:
+		return av.Binary()
+	case events.DataTypeBoolean:
+		return av.Boolean()
+	case events.DataTypeBinarySet:
+		return av.BinarySet()
+	case events.DataTypeList:
+		list := make([]any, 0, len(av.List()))
+		for _, v := range av.List() {
+			list = append(list, dynamoDBValue(v))
+		}
+
+		return list
+	case events.DataTypeMap:
+		return dynamoDBItem(av.Map())
+	case events.DataTypeNumber:
+		return json.Number(av.Number())
+	case events.DataTypeNumberSet:
+		set := make([]json.Number, 0, len(av.NumberSet()))
+		for _, n := range av.NumberSet() {
+			set = append(set, json.Number(n))
+		}
+
+		return set
+	case events.DataTypeString:
+		return av.String()
+	case events.DataTypeStringSet:
+		return av.StringSet()
+	default:
+		return nil
+	}
+}
@@ -1,15 +1,20 @@
 package request
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"strings"
 	"testing"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
 )
 
 const testPath = "/pets/luna"
@@ -607,3 +612,658 @@ func TestRequestInfo_newAPIGatewayV2RequestInfo(t *testing.T) {
 		assert.Equal(t, "value", v)
 	})
 }
+
+func TestRequestInfo_newALBRequestInfo(t *testing.T) {
+	t.Run("queryString", func(t *testing.T) {
+		e := events.ALBTargetGroupRequest{
+			HTTPMethod: http.MethodGet,
+			Path:       testPath,
+			QueryStringParameters: map[string]string{
+				"order":  "desc",
+				"fields": "name%2Cspecies",
+			},
+		}
+
+		r, err := newALBRequestInfo(e)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req, err := r.toRequest(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, http.MethodGet, req.Method)
+		assert.Equal(t, testPath, req.URL.Path)
+		assert.Equal(t, `desc`, req.URL.Query().Get("order"))
+		assert.Equal(t, `name,species`, req.URL.Query().Get("fields"))
+	})
+
+	t.Run("multiValueQueryString", func(t *testing.T) {
+		e := events.ALBTargetGroupRequest{
+			HTTPMethod: http.MethodGet,
+			Path:       testPath,
+			MultiValueQueryStringParameters: map[string][]string{
+				"multi_arr%5B%5D": {"arr1", "arr%202"},
+			},
+		}
+
+		r, err := newALBRequestInfo(e)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req, err := r.toRequest(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"arr1", "arr 2"}, req.URL.Query()["multi_arr[]"])
+	})
+
+	t.Run("remoteAddr", func(t *testing.T) {
+		e := events.ALBTargetGroupRequest{
+			HTTPMethod: http.MethodGet,
+			Path:       testPath,
+			Headers: map[string]string{
+				"x-forwarded-for": "1.2.3.4, 10.0.0.1",
+			},
+		}
+
+		r, err := newALBRequestInfo(e)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req, err := r.toRequest(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, `1.2.3.4`, req.RemoteAddr)
+	})
+
+	t.Run("multiHeader", func(t *testing.T) {
+		e := events.ALBTargetGroupRequest{
+			HTTPMethod: http.MethodPost,
+			Path:       testPath,
+			Body:       `{ "name": "Tobi" }`,
+			MultiValueHeaders: map[string][]string{
+				"content-type":    {"application/json"},
+				"host":            {"example.com"},
+				"x-custom":        {"apex1", "apex2"},
+				"x-forwarded-for": {"1.2.3.4"},
+			},
+		}
+
+		r, err := newALBRequestInfo(e)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req, err := r.toRequest(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, `example.com`, req.Host)
+		assert.Equal(t, `1.2.3.4`, req.RemoteAddr)
+		assert.Equal(t, `18`, req.Header.Get("Content-Length"))
+		assert.Equal(t, `application/json`, req.Header.Get("Content-Type"))
+		assert.Equal(t, []string{"apex1", "apex2"}, req.Header["X-Custom"])
+	})
+
+	t.Run("context", func(t *testing.T) {
+		e := events.ALBTargetGroupRequest{
+			HTTPMethod: http.MethodGet,
+			Path:       testPath,
+			RequestContext: events.ALBTargetGroupRequestContext{
+				ELB: events.ELBContext{TargetGroupArn: "arn:aws:elasticloadbalancing:target-group"},
+			},
+		}
+
+		r, err := newALBRequestInfo(e)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req, err := r.toRequest(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, e.RequestContext, req.Context().Value(ContextKey))
+	})
+}
+
+func TestRequestInfo_newFunctionURLRequestInfo(t *testing.T) {
+	t.Run("request", func(t *testing.T) {
+		e := events.LambdaFunctionURLRequest{
+			RawPath:        testPath,
+			RawQueryString: "order=desc",
+			Body:           `{ "name": "Tobi" }`,
+			Headers: map[string]string{
+				"Content-Type": "application/json",
+				"Host":         "example.com",
+			},
+			Cookies: []string{"foo=bar"},
+			RequestContext: events.LambdaFunctionURLRequestContext{
+				RequestID: "1234",
+				HTTP: events.LambdaFunctionURLRequestContextHTTPDescription{
+					Method:   http.MethodPost,
+					SourceIP: "1.2.3.4",
+				},
+			},
+		}
+
+		r := newFunctionURLRequestInfo(e)
+
+		req, err := r.toRequest(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, testPath+"?order=desc", req.RequestURI)
+		assert.Equal(t, `example.com`, req.Host)
+		assert.Equal(t, `1.2.3.4`, req.RemoteAddr)
+		assert.Equal(t, `1234`, req.Header.Get("X-Request-Id"))
+		assert.Equal(t, `application/json`, req.Header.Get("Content-Type"))
+		assert.Equal(t, `foo=bar`, req.Header.Get("Cookie"))
+	})
+
+	t.Run("iam", func(t *testing.T) {
+		iam := &events.LambdaFunctionURLRequestContextAuthorizerIAMDescription{
+			AccountID: "123456789012",
+			UserARN:   "arn:aws:iam::123456789012:user/luna",
+		}
+
+		e := events.LambdaFunctionURLRequest{
+			RawPath: testPath,
+			RequestContext: events.LambdaFunctionURLRequestContext{
+				Authorizer: &events.LambdaFunctionURLRequestContextAuthorizerDescription{IAM: iam},
+			},
+		}
+
+		req, err := NewFunctionURL(context.Background(), e)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		v, ok := FunctionURLIAM(req.Context())
+
+		assert.True(t, ok)
+		assert.Equal(t, iam, v)
+	})
+
+	t.Run("no iam", func(t *testing.T) {
+		req, err := NewFunctionURL(context.Background(), events.LambdaFunctionURLRequest{RawPath: testPath})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		v, ok := FunctionURLIAM(req.Context())
+
+		assert.False(t, ok)
+		assert.Nil(t, v)
+	})
+}
+
+func TestRequestInfo_newVPCLatticeV1RequestInfo(t *testing.T) {
+	e := VPCLatticeEventV1{
+		RawPath: testPath + "?order=desc",
+		Method:  http.MethodPost,
+		Body:    `{ "name": "Tobi" }`,
+		Headers: map[string]string{
+			"content-type":            "application/json",
+			"host":                    "pets.example.com",
+			"x-forwarded-for":         "10.0.0.1",
+			"x-amzn-source-vpc":       "vpc-0123456789",
+			"x-amzn-lattice-network":  "arn:aws:vpc-lattice:us-east-1:123456789012:servicenetwork/sn-123",
+			"x-amzn-lattice-identity": "Principal=arn:aws:iam::123456789012:role/caller; PrincipalOrgID=o-123; SessionName=session",
+		},
+		QueryStringParameters: map[string]string{"order": "desc"},
+	}
+
+	r, err := newVPCLatticeV1RequestInfo(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := r.toRequest(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, http.MethodPost, req.Method)
+	assert.Equal(t, testPath+"?order=desc", req.RequestURI)
+	assert.Equal(t, `pets.example.com`, req.Host)
+	assert.Equal(t, `10.0.0.1`, req.RemoteAddr)
+	assert.Equal(t, `application/json`, req.Header.Get("Content-Type"))
+
+	identity, ok := VPCLatticeIdentity(req.Context())
+
+	assert.True(t, ok)
+	assert.Equal(t, VPCLatticeRequestIdentity{
+		SourceVPCARN:   "vpc-0123456789",
+		Type:           "AWS_IAM",
+		Principal:      "arn:aws:iam::123456789012:role/caller",
+		PrincipalOrgID: "o-123",
+		SessionName:    "session",
+	}, identity)
+}
+
+func TestRequestInfo_newVPCLatticeV2RequestInfo(t *testing.T) {
+	e := VPCLatticeEventV2{
+		Version: "2.0",
+		Path:    testPath,
+		Method:  http.MethodGet,
+		Headers: map[string][]string{
+			"host":            {"pets.example.com"},
+			"x-forwarded-for": {"10.0.0.1"},
+			"x-custom":        {"apex1", "apex2"},
+		},
+		QueryStringParameters: map[string][]string{"fields": {"name", "species"}},
+		RequestContext: VPCLatticeRequestContext{
+			ServiceARN: "arn:aws:vpc-lattice:us-east-1:123456789012:service/svc-123",
+			Identity: VPCLatticeRequestIdentity{
+				SourceVPCARN: "arn:aws:ec2:us-east-1:123456789012:vpc/vpc-0123456789",
+				Type:         "AWS_IAM",
+				Principal:    "arn:aws:iam::123456789012:role/caller",
+			},
+		},
+	}
+
+	req, err := newVPCLatticeV2RequestInfo(e).toRequest(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, http.MethodGet, req.Method)
+	assert.Equal(t, []string{"name", "species"}, req.URL.Query()["fields"])
+	assert.Equal(t, `pets.example.com`, req.Host)
+	assert.Equal(t, `10.0.0.1`, req.RemoteAddr)
+	assert.Equal(t, []string{"apex1", "apex2"}, req.Header["X-Custom"])
+	assert.Equal(t, e.RequestContext, req.Context().Value(ContextKey))
+
+	identity, ok := VPCLatticeIdentity(req.Context())
+
+	assert.True(t, ok)
+	assert.Equal(t, e.RequestContext.Identity, identity)
+}
+
+func TestRequestInfo_newWebsocketRequestInfo(t *testing.T) {
+	t.Run("connect", func(t *testing.T) {
+		e := events.APIGatewayWebsocketProxyRequest{
+			Headers:               map[string]string{"Host": "ws.example.com"},
+			QueryStringParameters: map[string]string{"token": "abc"},
+			RequestContext: events.APIGatewayWebsocketProxyRequestContext{
+				RouteKey:     "$connect",
+				EventType:    "CONNECT",
+				ConnectionID: "conn-1",
+				RequestID:    "1234",
+				Stage:        "prod",
+				Identity:     events.APIGatewayRequestIdentity{SourceIP: "1.2.3.4"},
+			},
+		}
+
+		r, err := newWebsocketRequestInfo(e)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req, err := r.toRequest(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "/$connect", req.URL.Path)
+		assert.Equal(t, "abc", req.URL.Query().Get("token"))
+		assert.Equal(t, "ws.example.com", req.Host)
+		assert.Equal(t, "1.2.3.4", req.RemoteAddr)
+		assert.Equal(t, "conn-1", req.Header.Get(HeaderConnectionID))
+		assert.Equal(t, "$connect", req.Header.Get(HeaderRouteKey))
+		assert.Equal(t, "CONNECT", req.Header.Get(HeaderEventType))
+		assert.Equal(t, "1234", req.Header.Get("X-Request-Id"))
+		assert.Equal(t, e.RequestContext, req.Context().Value(ContextKey))
+	})
+
+	t.Run("custom route", func(t *testing.T) {
+		e := events.APIGatewayWebsocketProxyRequest{
+			Body: `{"action":"sendMessage","message":"hi"}`,
+			RequestContext: events.APIGatewayWebsocketProxyRequestContext{
+				RouteKey:     "sendMessage",
+				EventType:    "MESSAGE",
+				ConnectionID: "conn-1",
+			},
+		}
+
+		req, err := NewWebsocket(context.Background(), e)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := io.ReadAll(req.Body)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "/sendMessage", req.URL.Path)
+		assert.Equal(t, e.Body, string(b))
+	})
+}
+
+func TestRequestInfo_newCloudFrontRequestInfo(t *testing.T) {
+	e := CloudFrontEvent{
+		Records: []CloudFrontRecord{
+			{
+				CF: CloudFrontRecordData{
+					Config: CloudFrontConfig{RequestID: "1234"},
+					Request: CloudFrontRequest{
+						ClientIP:    "203.0.113.178",
+						Method:      http.MethodPost,
+						URI:         testPath,
+						Querystring: "size=large",
+						Headers: CloudFrontHeaders{
+							"host":     {{Key: "Host", Value: "example.com"}},
+							"x-custom": {{Key: "X-Custom", Value: "apex1"}, {Key: "X-Custom", Value: "apex2"}},
+						},
+						Body: &CloudFrontBody{Encoding: "base64", Data: "aGVsbG8gd29ybGQK"},
+					},
+				},
+			},
+		},
+	}
+
+	r, err := newCloudFrontRequestInfo(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := r.toRequest(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := io.ReadAll(req.Body)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "hello world\n", string(b))
+	assert.Equal(t, http.MethodPost, req.Method)
+	assert.Equal(t, testPath+"?size=large", req.RequestURI)
+	assert.Equal(t, "example.com", req.Host)
+	assert.Equal(t, "203.0.113.178", req.RemoteAddr)
+	assert.Equal(t, []string{"apex1", "apex2"}, req.Header["X-Custom"])
+	assert.False(t, ForwardToOrigin(req))
+}
+
+func TestRequestInfo_newAppSyncRequestInfo(t *testing.T) {
+	e := AppSyncResolverEvent{
+		Arguments: map[string]any{
+			"id":    "luna",
+			"input": map[string]any{"tags": []any{"cat", "indoor"}, "age": float64(3), "neutered": true, "owner": nil},
+		},
+		Identity: &AppSyncRequestIdentity{Sub: "user-1", Username: "tobi", SourceIP: []string{"203.0.113.178"}},
+		Request: AppSyncRequest{
+			Headers:    map[string]string{"host": "example.appsync-api.us-east-1.amazonaws.com", "x-custom": "apex"},
+			DomainName: "example.appsync-api.us-east-1.amazonaws.com",
+		},
+		Info: AppSyncInfo{
+			ParentTypeName:      "Mutation",
+			FieldName:           "updatePet",
+			SelectionSetGraphQL: "{\n  id\n  name\n}",
+		},
+	}
+
+	r, err := newAppSyncRequestInfo(e, "/graphql", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := r.toRequest(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := io.ReadAll(req.Body)
+
+	assert.NoError(t, err)
+	assert.JSONEq(
+		t,
+		`{"query":"mutation { updatePet(id: \"luna\", input: {age: 3, neutered: true, owner: null, tags: [\"cat\", \"indoor\"]}) {\n  id\n  name\n} }"}`,
+		string(b),
+	)
+	assert.Equal(t, http.MethodPost, req.Method)
+	assert.Equal(t, "/graphql", req.RequestURI)
+	assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+	assert.Equal(t, "apex", req.Header.Get("X-Custom"))
+	assert.Equal(t, "203.0.113.178", req.RemoteAddr)
+
+	identity, ok := AppSyncIdentity(req.Context())
+	assert.True(t, ok)
+	assert.Equal(t, "tobi", identity.Username)
+}
+
+func TestAppSyncQuery(t *testing.T) {
+	schema := gqlparser.MustLoadSchema(&ast.Source{Input: `
+		enum Status { ACTIVE ARCHIVED }
+
+		input PetInput { name: String! tags: [String!] age: Int status: Status }
+
+		type Owner { name: String! }
+		type Pet { id: ID! name: String! status: Status owner: Owner }
+
+		type Query {
+			getPet(id: ID!): Pet
+			listPets(status: Status, limit: Int): [Pet!]!
+		}
+
+		type Mutation {
+			updatePet(id: ID!, input: PetInput!): Pet
+		}
+	`})
+
+	validate := func(t *testing.T, query string) {
+		t.Helper()
+
+		_, errs := gqlparser.LoadQuery(schema, query)
+		assert.Empty(t, errs, query)
+	}
+
+	t.Run("query", func(t *testing.T) {
+		query, err := AppSyncQuery(AppSyncResolverEvent{
+			Arguments: map[string]any{"id": "luna"},
+			Info:      AppSyncInfo{ParentTypeName: "Query", FieldName: "getPet", SelectionSetGraphQL: "{\n  id\n  name\n}"},
+		})
+
+		assert.NoError(t, err)
+		validate(t, query)
+	})
+
+	t.Run("mutation with input object", func(t *testing.T) {
+		query, err := AppSyncQuery(AppSyncResolverEvent{
+			Arguments: map[string]any{
+				"id":    "luna",
+				"input": map[string]any{"name": "Luna", "tags": []any{"cat", "indoor"}, "age": float64(3), "status": GraphQLEnum("ACTIVE")},
+			},
+			Info: AppSyncInfo{ParentTypeName: "Mutation", FieldName: "updatePet", SelectionSetGraphQL: "{ id status }"},
+		})
+
+		assert.NoError(t, err)
+		validate(t, query)
+	})
+
+	t.Run("enum arguments", func(t *testing.T) {
+		evt := AppSyncResolverEvent{
+			Arguments: map[string]any{"status": "ACTIVE", "limit": float64(10)},
+			Info:      AppSyncInfo{ParentTypeName: "Query", FieldName: "listPets", SelectionSetGraphQL: "{ id }"},
+		}
+
+		// AppSync sends enums as strings, which are only valid once replaced with GraphQLEnum values
+		query, err := AppSyncQuery(evt)
+		assert.NoError(t, err)
+
+		_, errs := gqlparser.LoadQuery(schema, query)
+		assert.NotEmpty(t, errs)
+
+		evt.Arguments["status"] = GraphQLEnum("ACTIVE")
+
+		query, err = AppSyncQuery(evt)
+		assert.NoError(t, err)
+		validate(t, query)
+
+		evt.Arguments["status"] = GraphQLEnum("ACTIVE) { id } }")
+
+		_, err = AppSyncQuery(evt)
+		assert.Error(t, err)
+	})
+
+	t.Run("non root field", func(t *testing.T) {
+		_, err := AppSyncQuery(AppSyncResolverEvent{
+			Source: map[string]any{"ownerId": "tobi"},
+			Info:   AppSyncInfo{ParentTypeName: "Pet", FieldName: "owner", SelectionSetGraphQL: "{ name }"},
+		})
+
+		assert.ErrorIs(t, err, ErrUnsupportedAppSyncField)
+	})
+}
+
+func TestRequestInfo_newAuthorizerRequestInfo(t *testing.T) {
+	e := events.APIGatewayCustomAuthorizerRequestTypeRequest{
+		Type:                            "REQUEST",
+		MethodArn:                       "arn:aws:execute-api:us-east-1:123456789012:abcdef123/prod/GET/pets",
+		Path:                            testPath,
+		HTTPMethod:                      http.MethodGet,
+		Headers:                         map[string]string{"Authorization": "Bearer token"},
+		MultiValueQueryStringParameters: map[string][]string{"tag": {"cat", "dog"}},
+		RequestContext: events.APIGatewayCustomAuthorizerRequestTypeRequestContext{
+			RequestID: "1234",
+			Stage:     "prod",
+			Identity:  events.APIGatewayCustomAuthorizerRequestTypeRequestIdentity{SourceIP: "203.0.113.178"},
+		},
+	}
+
+	r, err := newAuthorizerRequestInfo(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := r.toRequest(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, http.MethodGet, req.Method)
+	assert.Equal(t, testPath+"?tag=cat&tag=dog", req.RequestURI)
+	assert.Equal(t, "Bearer token", req.Header.Get("Authorization"))
+	assert.Equal(t, e.MethodArn, req.Header.Get(HeaderMethodARN))
+	assert.Equal(t, "1234", req.Header.Get("X-Request-Id"))
+	assert.Equal(t, "prod", req.Header.Get("X-Stage"))
+	assert.Equal(t, "203.0.113.178", req.RemoteAddr)
+	assert.Len(t, e.Headers, 1)
+}
+
+func TestRequestInfo_newTokenAuthorizerRequestInfo(t *testing.T) {
+	e := events.APIGatewayCustomAuthorizerRequest{
+		Type:               "TOKEN",
+		AuthorizationToken: "Bearer 1234",
+		MethodArn:          "arn:aws:execute-api:us-east-1:123456789012:abcdef123/prod/POST/pets/luna/photos",
+	}
+
+	req, err := newTokenAuthorizerRequestInfo(e).toRequest(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, http.MethodPost, req.Method)
+	assert.Equal(t, testPath+"/photos", req.RequestURI)
+	assert.Equal(t, "Bearer 1234", req.Header.Get("Authorization"))
+	assert.Equal(t, e.MethodArn, req.Header.Get(HeaderMethodARN))
+	assert.Equal(t, "prod", req.Header.Get("X-Stage"))
+	assert.Equal(t, e, req.Context().Value(ContextKey))
+}
+
+func TestRequestInfo_newAuthorizerV2RequestInfo(t *testing.T) {
+	e := events.APIGatewayV2CustomAuthorizerV2Request{
+		RouteArn:       "arn:aws:execute-api:us-east-1:123456789012:abcdef123/$default/GET/pets",
+		RawPath:        testPath,
+		RawQueryString: "order=desc",
+		Cookies:        []string{"session=1234", "theme=dark"},
+		Headers:        map[string]string{"accept": "text/html,application/json"},
+		RequestContext: events.APIGatewayV2HTTPRequestContext{
+			RequestID: "1234",
+			Stage:     "$default",
+			HTTP:      events.APIGatewayV2HTTPRequestContextHTTPDescription{Method: http.MethodGet, SourceIP: "203.0.113.178"},
+		},
+	}
+
+	req, err := newAuthorizerV2RequestInfo(e).toRequest(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, http.MethodGet, req.Method)
+	assert.Equal(t, testPath+"?order=desc", req.RequestURI)
+	assert.Equal(t, []string{"text/html", "application/json"}, req.Header["Accept"])
+	assert.Equal(t, []string{"session=1234", "theme=dark"}, req.Header["Cookie"])
+	assert.Equal(t, e.RouteArn, req.Header.Get(HeaderMethodARN))
+	assert.Equal(t, "203.0.113.178", req.RemoteAddr)
+}
+
+func TestRequestInfo_newDirectInvokeRequestInfo(t *testing.T) {
+	e := DirectInvokeEvent{
+		Method:   http.MethodPost,
+		Path:     testPath + "?notify=true",
+		Headers:  map[string]string{"Content-Type": "application/octet-stream", "X-Request-Id": "1234"},
+		Body:     base64.StdEncoding.EncodeToString([]byte{0xff, 0xfe}),
+		IsBase64: true,
+	}
+
+	ri, err := newDirectInvokeRequestInfo(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := ri.toRequest(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, _ := io.ReadAll(req.Body)
+
+	assert.Equal(t, http.MethodPost, req.Method)
+	assert.Equal(t, testPath+"?notify=true", req.RequestURI)
+	assert.Equal(t, "application/octet-stream", req.Header.Get("Content-Type"))
+	assert.Equal(t, "1234", req.Header.Get("X-Request-Id"))
+	assert.Equal(t, []byte{0xff, 0xfe}, body)
+	assert.Equal(t, e, req.Context().Value(ContextKey))
+}
+
+func TestNewDirectInvokeEvent(t *testing.T) {
+	t.Run("text body", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/pets?notify=true", strings.NewReader(`{"name":"luna"}`))
+		r.Header.Set("Content-Type", "application/json")
+		r.Header.Add("Accept", "text/html")
+		r.Header.Add("Accept", "application/json")
+
+		evt, err := NewDirectInvokeEvent(r)
+
+		assert.NoError(t, err)
+		assert.Equal(t, DirectInvokeEvent{
+			Method:  http.MethodPost,
+			Path:    "/pets?notify=true",
+			Headers: map[string]string{"Content-Type": "application/json", "Accept": "text/html,application/json"},
+			Body:    `{"name":"luna"}`,
+		}, evt)
+	})
+
+	t.Run("binary body", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPut, "/pets/luna/photo", bytes.NewReader([]byte{0xff, 0xfe}))
+
+		evt, err := NewDirectInvokeEvent(r)
+
+		assert.NoError(t, err)
+		assert.True(t, evt.IsBase64)
+		assert.Equal(t, "//4=", evt.Body)
+	})
+}
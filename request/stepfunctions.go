@@ -0,0 +1,38 @@
+package request
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// StepFunctionsTask is the input of a Step Functions Lambda task, which can be any JSON value.
+type StepFunctionsTask json.RawMessage
+
+// MarshalJSON implementation.
+func (t StepFunctionsTask) MarshalJSON() ([]byte, error) {
+	return json.RawMessage(t).MarshalJSON()
+}
+
+// UnmarshalJSON implementation.
+func (t *StepFunctionsTask) UnmarshalJSON(b []byte) error {
+	return (*json.RawMessage)(t).UnmarshalJSON(b)
+}
+
+// Field returns the top level field of an object input, or nil when the input isn't an object or doesn't have it.
+func (t StepFunctionsTask) Field(name string) json.RawMessage {
+	var fields map[string]json.RawMessage
+
+	if err := json.Unmarshal(t, &fields); err != nil {
+		return nil
+	}
+
+	return fields[name]
+}
+
+// TaskToken returns the token of a Step Functions task invoked with the `.waitForTaskToken` integration pattern, used
+// to report the result with SendTaskSuccess or SendTaskFailure. The second value is false when the request didn't come
+// from such a task.
+func TaskToken(ctx context.Context) (string, bool) {
+	token, ok := ctx.Value(taskTokenKey).(string)
+	return token, ok
+}
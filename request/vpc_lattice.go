@@ -0,0 +1,104 @@
+package request
+
+import (
+	"context"
+	"strings"
+)
+
+// VPCLatticeEventV1 is the request sent by a VPC Lattice target group configured with the V1 event structure.
+type VPCLatticeEventV1 struct {
+	RawPath               string            `json:"raw_path"`
+	Method                string            `json:"method"`
+	Headers               map[string]string `json:"headers"`
+	QueryStringParameters map[string]string `json:"query_string_parameters"`
+	Body                  string            `json:"body"`
+	IsBase64Encoded       bool              `json:"is_base64_encoded"`
+}
+
+// VPCLatticeEventV2 is the request sent by a VPC Lattice target group configured with the V2 event structure.
+type VPCLatticeEventV2 struct {
+	Version               string                   `json:"version"`
+	Path                  string                   `json:"path"`
+	Method                string                   `json:"method"`
+	Headers               map[string][]string      `json:"headers"`
+	QueryStringParameters map[string][]string      `json:"queryStringParameters,omitempty"`
+	Body                  string                   `json:"body"`
+	IsBase64Encoded       bool                     `json:"isBase64Encoded"`
+	RequestContext        VPCLatticeRequestContext `json:"requestContext"`
+}
+
+// VPCLatticeRequestContext contains the information about the service network and the caller of a VPC Lattice
+// request. For V1 events it's built from the `x-amzn-*` headers added by VPC Lattice.
+type VPCLatticeRequestContext struct {
+	ServiceNetworkARN string                    `json:"serviceNetworkArn"`
+	ServiceARN        string                    `json:"serviceArn"`
+	TargetGroupARN    string                    `json:"targetGroupArn"`
+	Identity          VPCLatticeRequestIdentity `json:"identity"`
+	Region            string                    `json:"region"`
+	TimeEpoch         string                    `json:"timeEpoch"`
+}
+
+// VPCLatticeRequestIdentity contains the identity of the caller of a VPC Lattice request.
+type VPCLatticeRequestIdentity struct {
+	SourceVPCARN   string `json:"sourceVpcArn"`
+	Type           string `json:"type"`
+	Principal      string `json:"principal"`
+	PrincipalOrgID string `json:"principalOrgID"`
+	SessionName    string `json:"sessionName"`
+	X509SubjectCN  string `json:"x509SubjectCn"`
+	X509IssuerOU   string `json:"x509IssuerOu"`
+	X509SanDNS     string `json:"x509SanDns"`
+	X509SanNameCN  string `json:"x509SanNameCn"`
+	X509SanURI     string `json:"x509SanUri"`
+}
+
+// VPCLatticeIdentity returns the caller identity of a VPC Lattice request. The second value is false when the request
+// didn't come from VPC Lattice.
+func VPCLatticeIdentity(ctx context.Context) (VPCLatticeRequestIdentity, bool) {
+	reqCtx, ok := ctx.Value(ContextKey).(VPCLatticeRequestContext)
+	if !ok {
+		return VPCLatticeRequestIdentity{}, false
+	}
+
+	return reqCtx.Identity, true
+}
+
+// newVPCLatticeV1RequestContext builds the request context of a V1 event from the headers added by VPC Lattice.
+func newVPCLatticeV1RequestContext(headers map[string]string) VPCLatticeRequestContext {
+	h := make(map[string]string, len(headers))
+	for k, v := range headers {
+		h[strings.ToLower(k)] = v
+	}
+
+	reqCtx := VPCLatticeRequestContext{
+		ServiceNetworkARN: h["x-amzn-lattice-network"],
+		TargetGroupARN:    h["x-amzn-lattice-target"],
+		Identity: VPCLatticeRequestIdentity{
+			SourceVPCARN: h["x-amzn-source-vpc"],
+		},
+	}
+
+	// x-amzn-lattice-identity is only sent for AWS_IAM authenticated requests, e.g.
+	// "Principal=arn:aws:iam::123456789012:role/caller; PrincipalOrgID=o-123; SessionName=session"
+	identity, ok := h["x-amzn-lattice-identity"]
+	if !ok {
+		return reqCtx
+	}
+
+	reqCtx.Identity.Type = "AWS_IAM"
+
+	for _, part := range strings.Split(identity, ";") {
+		k, v, _ := strings.Cut(strings.TrimSpace(part), "=")
+
+		switch k {
+		case "Principal":
+			reqCtx.Identity.Principal = v
+		case "PrincipalOrgID":
+			reqCtx.Identity.PrincipalOrgID = v
+		case "SessionName":
+			reqCtx.Identity.SessionName = v
+		}
+	}
+
+	return reqCtx
+}
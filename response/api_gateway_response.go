@@ -1,5 +1,12 @@
 package response
 
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
 type APIGatewayResponse struct {
 	StatusCode        int                 `json:"statusCode"`
 	Headers           map[string]string   `json:"headers"`
@@ -31,3 +38,131 @@ func (agr APIGatewayResponse) ToV2Map() map[string]any {
 		"cookies":           agr.Cookies,
 	}
 }
+
+// ToWebsocketMap builds the API Gateway WebSocket integration response. On `$connect` a non 2xx status code rejects
+// the connection, and on routes with a two-way integration the body is sent back to the client.
+func (agr APIGatewayResponse) ToWebsocketMap() map[string]any {
+	return map[string]any{
+		"statusCode":      agr.StatusCode,
+		"headers":         agr.singleValueHeaders(),
+		"body":            agr.Body,
+		"isBase64Encoded": agr.IsBase64Encoded,
+	}
+}
+
+// ToFunctionURLMap builds the Lambda Function URL response. Function URLs don't support multi value headers, so
+// they are flattened into `headers`, and cookies are only sent through the `cookies` field.
+func (agr APIGatewayResponse) ToFunctionURLMap() map[string]any {
+	headers := agr.singleValueHeaders()
+
+	if len(agr.Cookies) > 0 {
+		for k := range headers {
+			if http.CanonicalHeaderKey(k) == "Set-Cookie" {
+				delete(headers, k)
+			}
+		}
+	}
+
+	return map[string]any{
+		"statusCode":      agr.StatusCode,
+		"headers":         headers,
+		"body":            agr.Body,
+		"isBase64Encoded": agr.IsBase64Encoded,
+		"cookies":         agr.Cookies,
+	}
+}
+
+// ToCloudFrontMap builds the Lambda@Edge generated response. CloudFront expects the status as a string and the
+// headers keyed by their lowercased name with a list of `{key, value}` objects.
+func (agr APIGatewayResponse) ToCloudFrontMap() map[string]any {
+	headers := make(map[string][]map[string]string)
+
+	for k, values := range agr.multiValueHeaders() {
+		name := strings.ToLower(k)
+
+		for _, v := range values {
+			headers[name] = append(headers[name], map[string]string{"key": k, "value": v})
+		}
+	}
+
+	bodyEncoding := "text"
+	if agr.IsBase64Encoded {
+		bodyEncoding = "base64"
+	}
+
+	return map[string]any{
+		"status":            strconv.Itoa(agr.StatusCode),
+		"statusDescription": http.StatusText(agr.StatusCode),
+		"headers":           headers,
+		"bodyEncoding":      bodyEncoding,
+		"body":              agr.Body,
+	}
+}
+
+// ToALBMap builds the ALB target group response. When multiValue is true the target group has multi-value headers
+// enabled, so every header is sent through `multiValueHeaders`, otherwise everything is flattened into `headers`.
+func (agr APIGatewayResponse) ToALBMap(multiValue bool) map[string]any {
+	res := map[string]any{
+		"statusCode":        agr.StatusCode,
+		"statusDescription": fmt.Sprintf("%d %s", agr.StatusCode, http.StatusText(agr.StatusCode)),
+		"body":              agr.Body,
+		"isBase64Encoded":   agr.IsBase64Encoded,
+	}
+
+	if multiValue {
+		res["multiValueHeaders"] = agr.multiValueHeaders()
+		return res
+	}
+
+	res["headers"] = agr.singleValueHeaders()
+
+	return res
+}
+
+// ToVPCLatticeMap builds the VPC Lattice response, which has the same shape for both event structure versions.
+// VPC Lattice doesn't support multi value headers, so they are flattened into `headers`.
+func (agr APIGatewayResponse) ToVPCLatticeMap() map[string]any {
+	return map[string]any{
+		"statusCode":        agr.StatusCode,
+		"statusDescription": fmt.Sprintf("%d %s", agr.StatusCode, http.StatusText(agr.StatusCode)),
+		"headers":           agr.singleValueHeaders(),
+		"body":              agr.Body,
+		"isBase64Encoded":   agr.IsBase64Encoded,
+	}
+}
+
+// multiValueHeaders merges Headers and MultiValueHeaders into a single multi value map.
+func (agr APIGatewayResponse) multiValueHeaders() map[string][]string {
+	headers := make(map[string][]string, len(agr.Headers)+len(agr.MultiValueHeaders))
+
+	for k, v := range agr.Headers {
+		headers[k] = append(headers[k], v)
+	}
+
+	for k, values := range agr.MultiValueHeaders {
+		headers[k] = append(headers[k], values...)
+	}
+
+	return headers
+}
+
+// singleValueHeaders flattens Headers and MultiValueHeaders into a single value map. Repeated values are joined with
+// a comma except for Set-Cookie, which can't be combined, so only the last one is kept.
+func (agr APIGatewayResponse) singleValueHeaders() map[string]string {
+	headers := make(map[string]string, len(agr.Headers)+len(agr.MultiValueHeaders))
+
+	for k, values := range agr.multiValueHeaders() {
+		if len(values) == 0 {
+			continue
+		}
+
+		if http.CanonicalHeaderKey(k) == "Set-Cookie" {
+			headers[k] = values[len(values)-1]
+			continue
+		}
+
+		headers[k] = strings.Join(values, ",")
+	}
+
+	return headers
+}
@@ -0,0 +1,49 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// Policy effects of the statements returned by API Gateway Lambda authorizers.
+const (
+	EffectAllow = "Allow"
+	EffectDeny  = "Deny"
+)
+
+// AuthorizerPolicy builds the IAM policy returned by an API Gateway Lambda authorizer, allowing or denying the
+// principal to invoke the resources. The context values are passed to the integration in
+// `requestContext.authorizer`.
+func AuthorizerPolicy(principalID, effect string, context map[string]any, resources ...string) events.APIGatewayCustomAuthorizerResponse {
+	return events.APIGatewayCustomAuthorizerResponse{
+		PrincipalID: principalID,
+		PolicyDocument: events.APIGatewayCustomAuthorizerPolicy{
+			Version: "2012-10-17",
+			Statement: []events.IAMPolicyStatement{
+				{
+					Action:   []string{"execute-api:Invoke"},
+					Effect:   effect,
+					Resource: resources,
+				},
+			},
+		},
+		Context: context,
+	}
+}
+
+// WriteAuthorizerPolicy writes the policy as the handler response of an API Gateway Lambda authorizer request.
+func WriteAuthorizerPolicy(w http.ResponseWriter, policy events.APIGatewayCustomAuthorizerResponse) error {
+	b, err := json.Marshal(policy)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	_, err = w.Write(b)
+
+	return err
+}
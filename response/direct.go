@@ -0,0 +1,53 @@
+package response
+
+import (
+	"encoding/base64"
+	"net/http"
+)
+
+// DirectInvokeResponse is the lamway response to a request.DirectInvokeEvent. Repeated headers are joined with a
+// comma and cookies are only sent through Cookies, while binary bodies are base64 encoded with IsBase64 set.
+type DirectInvokeResponse struct {
+	StatusCode int               `json:"statusCode"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Cookies    []string          `json:"cookies,omitempty"`
+	Body       string            `json:"body,omitempty"`
+	IsBase64   bool              `json:"isBase64,omitempty"`
+}
+
+// DecodeBody returns the body of the response, decoding it when it is base64 encoded.
+func (r DirectInvokeResponse) DecodeBody() ([]byte, error) {
+	if r.IsBase64 {
+		return base64.StdEncoding.DecodeString(r.Body)
+	}
+
+	return []byte(r.Body), nil
+}
+
+// ToDirectInvoke builds the response to a direct invocation using the lamway envelope. Handlers that don't write
+// anything answer with a 200 and an empty body.
+func (agr APIGatewayResponse) ToDirectInvoke() DirectInvokeResponse {
+	headers := agr.singleValueHeaders()
+
+	if len(agr.Cookies) > 0 {
+		for k := range headers {
+			if http.CanonicalHeaderKey(k) == "Set-Cookie" {
+				delete(headers, k)
+			}
+		}
+	}
+
+	res := DirectInvokeResponse{
+		StatusCode: agr.StatusCode,
+		Headers:    headers,
+		Cookies:    agr.Cookies,
+		Body:       agr.Body,
+		IsBase64:   agr.IsBase64Encoded && agr.Body != "",
+	}
+
+	if res.StatusCode == 0 {
+		res.StatusCode = http.StatusOK
+	}
+
+	return res
+}
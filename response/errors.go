@@ -4,4 +4,6 @@ import "errors"
 
 var (
 	ErrResponseVersionNotSupported = errors.New("gateway[response]: version not supported")
+	ErrSSEClosed                   = errors.New("gateway[response]: server-sent events stream closed")
+	ErrInvalidSSEField             = errors.New("gateway[response]: server-sent events id and event can't contain line breaks")
 )
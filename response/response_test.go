@@ -136,3 +136,72 @@ func TestResponseWriter_WriteHeader(t *testing.T) {
 	assert.Equal(t, "Not Found\n", e.Body)
 	assert.Equal(t, "text/plain; charset=utf8", e.Headers["Content-Type"])
 }
+
+func TestAPIGatewayResponse_ToALBMap(t *testing.T) {
+	res := APIGatewayResponse{
+		StatusCode:        404,
+		Headers:           map[string]string{"Content-Type": "text/plain"},
+		MultiValueHeaders: map[string][]string{"X-Foo": {"foo1", "foo2"}, "Set-Cookie": {"a=1", "b=2"}},
+		Body:              "Not Found\n",
+	}
+
+	t.Run("single value headers", func(t *testing.T) {
+		m := res.ToALBMap(false)
+
+		assert.Equal(t, 404, m["statusCode"])
+		assert.Equal(t, "404 Not Found", m["statusDescription"])
+		assert.Equal(t, "Not Found\n", m["body"])
+		assert.Equal(t, map[string]string{"Content-Type": "text/plain", "X-Foo": "foo1,foo2", "Set-Cookie": "b=2"}, m["headers"])
+		assert.NotContains(t, m, "multiValueHeaders")
+	})
+
+	t.Run("multi value headers", func(t *testing.T) {
+		m := res.ToALBMap(true)
+
+		assert.Equal(t, map[string][]string{"Content-Type": {"text/plain"}, "X-Foo": {"foo1", "foo2"}, "Set-Cookie": {"a=1", "b=2"}}, m["multiValueHeaders"])
+		assert.NotContains(t, m, "headers")
+	})
+}
+
+func TestAPIGatewayResponse_ToFunctionURLMap(t *testing.T) {
+	res := APIGatewayResponse{
+		StatusCode:        200,
+		Headers:           map[string]string{"Content-Type": "text/plain", "Set-Cookie": "a=1"},
+		MultiValueHeaders: map[string][]string{"X-Foo": {"foo1", "foo2"}},
+		Body:              "hello world\n",
+		Cookies:           []string{"a=1"},
+	}
+
+	m := res.ToFunctionURLMap()
+
+	assert.Equal(t, 200, m["statusCode"])
+	assert.Equal(t, map[string]string{"Content-Type": "text/plain", "X-Foo": "foo1,foo2"}, m["headers"])
+	assert.Equal(t, []string{"a=1"}, m["cookies"])
+	assert.NotContains(t, m, "multiValueHeaders")
+}
+
+func TestAPIGatewayResponse_ToDirectInvoke(t *testing.T) {
+	t.Run("silent handler", func(t *testing.T) {
+		w := New()
+
+		res := w.End().ToDirectInvoke()
+
+		assert.Equal(t, 200, res.StatusCode)
+		assert.False(t, res.IsBase64)
+		assert.Empty(t, res.Body)
+	})
+
+	t.Run("binary body", func(t *testing.T) {
+		w := New()
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write([]byte{0x89, 0x50})
+
+		res := w.End().ToDirectInvoke()
+
+		body, err := res.DecodeBody()
+
+		assert.NoError(t, err)
+		assert.True(t, res.IsBase64)
+		assert.Equal(t, []byte{0x89, 0x50}, body)
+	})
+}
@@ -0,0 +1,183 @@
+package response
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultSSEHeartbeat      = 15 * time.Second
+	defaultSSEDeadlineMargin = time.Second
+)
+
+// sseLineBreaks normalizes the line breaks accepted by the Server-Sent Events format.
+var sseLineBreaks = strings.NewReplacer("\r\n", "\n", "\r", "\n")
+
+// Event is a single Server-Sent Events frame.
+type Event struct {
+	ID    string
+	Event string
+	Data  string
+	Retry time.Duration
+}
+
+// SSEOption is a functional option for configuring the SSEWriter.
+type SSEOption func(*SSEWriter)
+
+// WithHeartbeat sets the interval used to send keep-alive comments while streaming. A zero or negative value
+// disables them.
+func WithHeartbeat(d time.Duration) SSEOption {
+	return func(s *SSEWriter) {
+		s.heartbeat = d
+	}
+}
+
+// WithDeadlineMargin sets how long before the Lambda invocation deadline the stream is ended.
+func WithDeadlineMargin(d time.Duration) SSEOption {
+	return func(s *SSEWriter) {
+		s.deadlineMargin = d
+	}
+}
+
+// SSEWriter writes Server-Sent Events on top of an http.ResponseWriter. When the writer implements http.Flusher, as
+// the streaming gateway one does, every event is sent right away and heartbeats keep the connection open. Otherwise,
+// like on API Gateway v1, the events are buffered and sent as a single response when the handler returns.
+type SSEWriter struct {
+	w              http.ResponseWriter
+	flusher        http.Flusher
+	heartbeat      time.Duration
+	deadlineMargin time.Duration
+	ctx            context.Context
+	cancel         context.CancelFunc
+	mu             sync.Mutex
+	closed         bool
+	wg             sync.WaitGroup
+}
+
+// NewSSE sets the event stream headers on w and returns a writer to send events through it. The stream ends when
+// Close is called, when ctx is done or when the ctx deadline, usually the Lambda invocation one, is about to expire.
+func NewSSE(ctx context.Context, w http.ResponseWriter, opts ...SSEOption) *SSEWriter {
+	s := &SSEWriter{
+		w:              w,
+		heartbeat:      defaultSSEHeartbeat,
+		deadlineMargin: defaultSSEDeadlineMargin,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		s.ctx, s.cancel = context.WithDeadline(ctx, deadline.Add(-s.deadlineMargin))
+	} else {
+		s.ctx, s.cancel = context.WithCancel(ctx)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	if flusher, ok := w.(http.Flusher); ok {
+		s.flusher = flusher
+		s.flusher.Flush()
+
+		if s.heartbeat > 0 {
+			s.wg.Add(1)
+			go s.keepAlive()
+		}
+	}
+
+	return s
+}
+
+// Streaming reports whether events reach the client as they are sent or are buffered until the handler returns.
+func (s *SSEWriter) Streaming() bool {
+	return s.flusher != nil
+}
+
+// Done returns a channel that is closed when the stream is ended, so the handler should stop producing events.
+func (s *SSEWriter) Done() <-chan struct{} {
+	return s.ctx.Done()
+}
+
+// Send writes the event to the stream. Line breaks in Data are sent as multiple data fields, while an ID or Event
+// containing one is rejected with ErrInvalidSSEField, as it would inject fields or frames into the stream.
+func (s *SSEWriter) Send(evt Event) error {
+	if strings.ContainsAny(evt.ID, "\r\n") || strings.ContainsAny(evt.Event, "\r\n") {
+		return ErrInvalidSSEField
+	}
+
+	var b strings.Builder
+
+	if evt.ID != "" {
+		b.WriteString("id: " + evt.ID + "\n")
+	}
+
+	if evt.Event != "" {
+		b.WriteString("event: " + evt.Event + "\n")
+	}
+
+	if evt.Retry > 0 {
+		b.WriteString(fmt.Sprintf("retry: %d\n", evt.Retry.Milliseconds()))
+	}
+
+	for _, line := range strings.Split(sseLineBreaks.Replace(evt.Data), "\n") {
+		b.WriteString("data: " + line + "\n")
+	}
+
+	b.WriteString("\n")
+
+	return s.write(b.String())
+}
+
+// Close ends the stream and stops the heartbeats. It must be called before the handler returns.
+func (s *SSEWriter) Close() {
+	s.cancel()
+	s.wg.Wait()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+}
+
+func (s *SSEWriter) write(frame string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed || s.ctx.Err() != nil {
+		return ErrSSEClosed
+	}
+
+	if _, err := s.w.Write([]byte(frame)); err != nil {
+		return err
+	}
+
+	if s.flusher != nil {
+		s.flusher.Flush()
+	}
+
+	return nil
+}
+
+func (s *SSEWriter) keepAlive() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.write(": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+	}
+}
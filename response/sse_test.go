@@ -0,0 +1,89 @@
+package response
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSSEWriter_Send(t *testing.T) {
+	t.Run("buffered", func(t *testing.T) {
+		w := New()
+
+		sse := NewSSE(context.Background(), w)
+
+		assert.False(t, sse.Streaming())
+		assert.NoError(t, sse.Send(Event{ID: "1", Event: "update", Data: "line 1\nline 2"}))
+		assert.NoError(t, sse.Send(Event{Data: "ping", Retry: 3 * time.Second}))
+
+		sse.Close()
+
+		assert.ErrorIs(t, sse.Send(Event{Data: "late"}), ErrSSEClosed)
+
+		e := w.End()
+		assert.Equal(t, 200, e.StatusCode)
+		assert.Equal(t, "text/event-stream", e.Headers["Content-Type"])
+		assert.Equal(t, "no-cache", e.Headers["Cache-Control"])
+		assert.Equal(t, "id: 1\nevent: update\ndata: line 1\ndata: line 2\n\nretry: 3000\ndata: ping\n\n", e.Body)
+	})
+
+	t.Run("line breaks", func(t *testing.T) {
+		w := New()
+
+		sse := NewSSE(context.Background(), w)
+
+		assert.NoError(t, sse.Send(Event{Data: "line 1\r\nline 2\rline 3\nline 4"}))
+		assert.ErrorIs(t, sse.Send(Event{ID: "1\ndata: injected", Data: "ping"}), ErrInvalidSSEField)
+		assert.ErrorIs(t, sse.Send(Event{Event: "update\r\rdata: injected", Data: "ping"}), ErrInvalidSSEField)
+
+		sse.Close()
+
+		assert.Equal(t, "data: line 1\ndata: line 2\ndata: line 3\ndata: line 4\n\n", w.End().Body)
+	})
+
+	t.Run("streaming", func(t *testing.T) {
+		w := NewStream()
+
+		go func() {
+			sse := NewSSE(context.Background(), w, WithHeartbeat(10*time.Millisecond))
+
+			assert.True(t, sse.Streaming())
+			assert.NoError(t, sse.Send(Event{Event: "update", Data: "hello"}))
+
+			time.Sleep(25 * time.Millisecond)
+
+			sse.Close()
+			_ = w.Close()
+		}()
+
+		<-w.Ready()
+
+		res := w.Response()
+		assert.Equal(t, "text/event-stream", res.Headers["Content-Type"])
+
+		body, err := io.ReadAll(res.Body)
+
+		assert.NoError(t, err)
+		assert.Contains(t, string(body), "event: update\ndata: hello\n\n")
+		assert.Contains(t, string(body), ": heartbeat\n\n")
+	})
+
+	t.Run("deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		sse := NewSSE(ctx, New(), WithDeadlineMargin(time.Minute-20*time.Millisecond))
+		defer sse.Close()
+
+		select {
+		case <-sse.Done():
+		case <-time.After(time.Second):
+			t.Fatal("stream was not ended before the deadline")
+		}
+
+		assert.ErrorIs(t, sse.Send(Event{Data: "late"}), ErrSSEClosed)
+	})
+}
@@ -0,0 +1,138 @@
+package response
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// StreamWriter implements the http.ResponseWriter and http.Flusher interfaces
+// to send the http output through a Lambda response stream instead of buffering it.
+type StreamWriter struct {
+	out           events.LambdaFunctionURLStreamingResponse
+	header        http.Header
+	wroteHeader   bool
+	ready         chan struct{}
+	reader        *io.PipeReader
+	writer        *io.PipeWriter
+	buf           *bufio.Writer
+	closeNotifyCh chan bool
+}
+
+// NewStream returns a new response writer to stream http output.
+func NewStream() *StreamWriter {
+	pr, pw := io.Pipe()
+
+	return &StreamWriter{
+		ready:         make(chan struct{}),
+		reader:        pr,
+		writer:        pw,
+		buf:           bufio.NewWriter(pw),
+		closeNotifyCh: make(chan bool, 1),
+	}
+}
+
+// Header implementation.
+func (w *StreamWriter) Header() http.Header {
+	if w.header == nil {
+		w.header = make(http.Header)
+	}
+
+	return w.header
+}
+
+// Write implementation. Bytes are kept in a small buffer until it fills up or Flush is called.
+func (w *StreamWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	return w.buf.Write(b)
+}
+
+// WriteHeader implementation. Once called the status code and headers are sent and can't be changed anymore.
+func (w *StreamWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "text/plain; charset=utf8")
+	}
+
+	w.out.StatusCode = status
+
+	h := make(map[string]string)
+
+	for k, v := range w.Header() {
+		if k == "Set-Cookie" {
+			continue
+		}
+
+		h[k] = strings.Join(v, ",")
+	}
+
+	w.out.Headers = h
+	w.out.Cookies = w.header["Set-Cookie"]
+	w.out.Body = w.reader
+	w.wroteHeader = true
+
+	close(w.ready)
+}
+
+// Flush implementation. Sends the buffered bytes to the client.
+func (w *StreamWriter) Flush() {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	_ = w.buf.Flush()
+}
+
+// CloseNotify notify when the response is closed
+func (w *StreamWriter) CloseNotify() <-chan bool {
+	return w.closeNotifyCh
+}
+
+// Ready returns a channel that is closed once the status code and headers are set and the body can start streaming.
+func (w *StreamWriter) Ready() <-chan struct{} {
+	return w.ready
+}
+
+// Close flushes any pending bytes and ends the stream.
+func (w *StreamWriter) Close() error {
+	w.Flush()
+
+	// notify end
+	w.closeNotifyCh <- true
+
+	return w.writer.Close()
+}
+
+// CloseWithError ends the stream making the reader side fail with the provided error. If nothing was sent yet the
+// response is marked as an internal server error.
+func (w *StreamWriter) CloseWithError(err error) error {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+
+	// notify end
+	w.closeNotifyCh <- true
+
+	return w.writer.CloseWithError(err)
+}
+
+// Cancel ends the stream from the reading side, so pending and later writes fail with err instead of blocking once
+// the response isn't read anymore.
+func (w *StreamWriter) Cancel(err error) {
+	_ = w.reader.CloseWithError(err)
+}
+
+// Response returns the Lambda streaming response that reads from this writer. It should only be used after Ready
+// is closed, otherwise the status code and headers are not set yet.
+func (w *StreamWriter) Response() *events.LambdaFunctionURLStreamingResponse {
+	return &w.out
+}
@@ -0,0 +1,76 @@
+package response
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamWriter_Write(t *testing.T) {
+	t.Run("headers", func(t *testing.T) {
+		w := NewStream()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Add("Set-Cookie", "a=1")
+		w.Header().Add("X-Foo", "foo1")
+		w.Header().Add("X-Foo", "foo2")
+		w.WriteHeader(http.StatusAccepted)
+
+		<-w.Ready()
+
+		res := w.Response()
+		assert.Equal(t, http.StatusAccepted, res.StatusCode)
+		assert.Equal(t, map[string]string{"Content-Type": "text/event-stream", "X-Foo": "foo1,foo2"}, res.Headers)
+		assert.Equal(t, []string{"a=1"}, res.Cookies)
+	})
+
+	t.Run("flush", func(t *testing.T) {
+		w := NewStream()
+
+		go func() {
+			_, _ = w.Write([]byte("hello "))
+			w.Flush()
+
+			_, _ = w.Write([]byte("world\n"))
+			_ = w.Close()
+		}()
+
+		<-w.Ready()
+
+		res := w.Response()
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "text/plain; charset=utf8", res.Headers["Content-Type"])
+
+		first := make([]byte, 6)
+		_, err := io.ReadFull(res.Body, first)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "hello ", string(first))
+
+		rest, err := io.ReadAll(res.Body)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "world\n", string(rest))
+		assert.True(t, <-w.CloseNotify())
+	})
+
+	t.Run("close with error", func(t *testing.T) {
+		w := NewStream()
+		errPanic := errors.New("handler failed")
+
+		go func() {
+			_ = w.CloseWithError(errPanic)
+		}()
+
+		<-w.Ready()
+
+		res := w.Response()
+		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+
+		_, err := io.ReadAll(res.Body)
+		assert.ErrorIs(t, err, errPanic)
+	})
+}
@@ -0,0 +1,42 @@
+package websocket
+
+import (
+	"context"
+	"time"
+
+	"github.com/danteay/lamway/request"
+)
+
+// ConnectionManager sends data to and manages the clients connected to an API Gateway WebSocket API.
+type ConnectionManager interface {
+	// PostToConnection sends data to the connected client.
+	PostToConnection(ctx context.Context, connectionID string, data []byte) error
+	// DeleteConnection disconnects the client.
+	DeleteConnection(ctx context.Context, connectionID string) error
+	// GetConnection returns the information about the connected client.
+	GetConnection(ctx context.Context, connectionID string) (Connection, error)
+}
+
+// Connection is the information API Gateway keeps about a connected client.
+type Connection struct {
+	ConnectedAt  time.Time          `json:"connectedAt"`
+	LastActiveAt time.Time          `json:"lastActiveAt"`
+	Identity     ConnectionIdentity `json:"identity"`
+}
+
+// ConnectionIdentity identifies the connected client.
+type ConnectionIdentity struct {
+	SourceIP  string `json:"sourceIp"`
+	UserAgent string `json:"userAgent"`
+}
+
+// NewContext returns a copy of ctx carrying the ConnectionManager.
+func NewContext(ctx context.Context, m ConnectionManager) context.Context {
+	return context.WithValue(ctx, request.ConnectionManagerKey, m)
+}
+
+// FromContext returns the ConnectionManager added by the gateway to the context of WebSocket requests.
+func FromContext(ctx context.Context) (ConnectionManager, bool) {
+	m, ok := ctx.Value(request.ConnectionManagerKey).(ConnectionManager)
+	return m, ok
+}
@@ -0,0 +1,9 @@
+package websocket
+
+import "errors"
+
+var (
+	ErrConnectionGone    = errors.New("gateway[websocket]: connection is gone")
+	ErrUnexpectedStatus  = errors.New("gateway[websocket]: unexpected status code")
+	ErrMissingCredential = errors.New("gateway[websocket]: missing AWS credentials")
+)
@@ -0,0 +1,149 @@
+package websocket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// Option is a functional option for configuring the HTTPConnectionManager.
+type Option func(*HTTPConnectionManager)
+
+// WithHTTPClient sets the http.Client used to call the `@connections` endpoint.
+func WithHTTPClient(c *http.Client) Option {
+	return func(m *HTTPConnectionManager) {
+		m.client = c
+	}
+}
+
+// WithCredentials sets the AWS credentials used to sign the requests. By default they are read from the
+// environment variables set by the Lambda runtime.
+func WithCredentials(c Credentials) Option {
+	return func(m *HTTPConnectionManager) {
+		m.credentials = c
+	}
+}
+
+// WithRegion sets the AWS region used to sign the requests. By default it's taken from the endpoint host or the
+// AWS_REGION environment variable.
+func WithRegion(region string) Option {
+	return func(m *HTTPConnectionManager) {
+		m.region = region
+	}
+}
+
+// HTTPConnectionManager implements ConnectionManager calling the API Gateway `@connections` endpoint.
+type HTTPConnectionManager struct {
+	endpoint    string
+	region      string
+	credentials Credentials
+	client      *http.Client
+	now         func() time.Time
+}
+
+// NewHTTPConnectionManager creates a ConnectionManager for the WebSocket API deployed at endpoint, which has the form
+// `https://{api-id}.execute-api.{region}.amazonaws.com/{stage}` or the custom domain equivalent.
+func NewHTTPConnectionManager(endpoint string, opts ...Option) *HTTPConnectionManager {
+	m := &HTTPConnectionManager{
+		endpoint:    strings.TrimSuffix(endpoint, "/"),
+		region:      regionFromEndpoint(endpoint),
+		credentials: CredentialsFromEnv(),
+		client:      http.DefaultClient,
+		now:         time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// PostToConnection implementation.
+func (m *HTTPConnectionManager) PostToConnection(ctx context.Context, connectionID string, data []byte) error {
+	_, err := m.do(ctx, http.MethodPost, connectionID, data)
+	return err
+}
+
+// DeleteConnection implementation.
+func (m *HTTPConnectionManager) DeleteConnection(ctx context.Context, connectionID string) error {
+	_, err := m.do(ctx, http.MethodDelete, connectionID, nil)
+	return err
+}
+
+// GetConnection implementation.
+func (m *HTTPConnectionManager) GetConnection(ctx context.Context, connectionID string) (Connection, error) {
+	body, err := m.do(ctx, http.MethodGet, connectionID, nil)
+	if err != nil {
+		return Connection{}, err
+	}
+
+	var conn Connection
+
+	if errDecode := json.Unmarshal(body, &conn); errDecode != nil {
+		return Connection{}, errDecode
+	}
+
+	return conn, nil
+}
+
+func (m *HTTPConnectionManager) do(ctx context.Context, method, connectionID string, data []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(
+		ctx,
+		method,
+		m.endpoint+"/@connections/"+url.PathEscape(connectionID),
+		bytes.NewReader(data),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if errSign := m.credentials.sign(req, data, m.region, signingService, m.now()); errSign != nil {
+		return nil, errSign
+	}
+
+	res, err := m.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case res.StatusCode == http.StatusGone:
+		return nil, ErrConnectionGone
+	case res.StatusCode < 200 || res.StatusCode > 299:
+		return nil, fmt.Errorf("%w: %d %s", ErrUnexpectedStatus, res.StatusCode, strings.TrimSpace(string(body)))
+	default:
+		return body, nil
+	}
+}
+
+// regionFromEndpoint extracts the region of a `{api-id}.execute-api.{region}.amazonaws.com` endpoint, falling back
+// to the AWS_REGION environment variable for custom domains.
+func regionFromEndpoint(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err == nil {
+		parts := strings.Split(u.Hostname(), ".")
+
+		if len(parts) > 3 && parts[1] == "execute-api" {
+			return parts[2]
+		}
+	}
+
+	return os.Getenv("AWS_REGION")
+}
@@ -0,0 +1,94 @@
+package websocket
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryConnectionManager implements ConnectionManager in memory recording the posted messages, so handlers can be
+// tested without calling AWS.
+type MemoryConnectionManager struct {
+	mu           sync.Mutex
+	connections  map[string]Connection
+	disconnected map[string]bool
+	messages     map[string][][]byte
+}
+
+// NewMemoryConnectionManager creates an empty MemoryConnectionManager.
+func NewMemoryConnectionManager() *MemoryConnectionManager {
+	return &MemoryConnectionManager{
+		connections:  make(map[string]Connection),
+		disconnected: make(map[string]bool),
+		messages:     make(map[string][][]byte),
+	}
+}
+
+// Connect registers a connected client, so it can be returned by GetConnection.
+func (m *MemoryConnectionManager) Connect(connectionID string, identity ConnectionIdentity) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+
+	m.connections[connectionID] = Connection{ConnectedAt: now, LastActiveAt: now, Identity: identity}
+	delete(m.disconnected, connectionID)
+}
+
+// PostToConnection implementation. Fails with ErrConnectionGone if the connection was deleted.
+func (m *MemoryConnectionManager) PostToConnection(_ context.Context, connectionID string, data []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.disconnected[connectionID] {
+		return ErrConnectionGone
+	}
+
+	m.messages[connectionID] = append(m.messages[connectionID], append([]byte(nil), data...))
+
+	return nil
+}
+
+// DeleteConnection implementation.
+func (m *MemoryConnectionManager) DeleteConnection(_ context.Context, connectionID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.disconnected[connectionID] {
+		return ErrConnectionGone
+	}
+
+	delete(m.connections, connectionID)
+	m.disconnected[connectionID] = true
+
+	return nil
+}
+
+// GetConnection implementation. Fails with ErrConnectionGone if the connection wasn't registered with Connect.
+func (m *MemoryConnectionManager) GetConnection(_ context.Context, connectionID string) (Connection, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	conn, ok := m.connections[connectionID]
+	if !ok {
+		return Connection{}, ErrConnectionGone
+	}
+
+	return conn, nil
+}
+
+// Messages returns the messages posted to the connection in order.
+func (m *MemoryConnectionManager) Messages(connectionID string) [][]byte {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([][]byte(nil), m.messages[connectionID]...)
+}
+
+// Disconnected reports whether the connection was deleted.
+func (m *MemoryConnectionManager) Disconnected(connectionID string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.disconnected[connectionID]
+}
@@ -0,0 +1,142 @@
+package websocket
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testCredentials = Credentials{
+	AccessKeyID:     "AKIDEXAMPLE",
+	SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+}
+
+func TestCredentials_sign(t *testing.T) {
+	// get-vanilla case from the AWS Signature Version 4 test suite
+	req, err := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC)
+
+	assert.NoError(t, testCredentials.sign(req, nil, "us-east-1", "service", now))
+	assert.Equal(t, "20150830T123600Z", req.Header.Get("X-Amz-Date"))
+	assert.Equal(
+		t,
+		"AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+		req.Header.Get("Authorization"),
+	)
+
+	assert.ErrorIs(t, Credentials{}.sign(req, nil, "us-east-1", "service", now), ErrMissingCredential)
+}
+
+func TestHTTPConnectionManager(t *testing.T) {
+	var (
+		method string
+		path   string
+		body   string
+		auth   string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+
+		method, path, body, auth = r.Method, r.URL.EscapedPath(), string(b), r.Header.Get("Authorization")
+
+		switch {
+		case strings.HasSuffix(path, "gone"):
+			w.WriteHeader(http.StatusGone)
+		case strings.HasSuffix(path, "forbidden"):
+			w.WriteHeader(http.StatusForbidden)
+		case r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`{"connectedAt":"2024-01-02T03:04:05Z","lastActiveAt":"2024-01-02T03:05:05Z","identity":{"sourceIp":"1.2.3.4","userAgent":"test"}}`))
+		}
+	}))
+	defer srv.Close()
+
+	m := NewHTTPConnectionManager(srv.URL+"/prod/", WithCredentials(testCredentials), WithRegion("us-east-1"))
+
+	t.Run("post", func(t *testing.T) {
+		err := m.PostToConnection(context.Background(), "L0SM9cOFvHcCIhw=", []byte("hello"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.MethodPost, method)
+		assert.Equal(t, "/prod/@connections/L0SM9cOFvHcCIhw=", path)
+		assert.Equal(t, "hello", body)
+		assert.Contains(t, auth, "Credential=AKIDEXAMPLE/")
+		assert.Contains(t, auth, "/us-east-1/execute-api/aws4_request")
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		err := m.DeleteConnection(context.Background(), "conn-1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.MethodDelete, method)
+		assert.Equal(t, "/prod/@connections/conn-1", path)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		conn, err := m.GetConnection(context.Background(), "conn-1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.MethodGet, method)
+		assert.Equal(t, ConnectionIdentity{SourceIP: "1.2.3.4", UserAgent: "test"}, conn.Identity)
+		assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), conn.ConnectedAt)
+	})
+
+	t.Run("gone", func(t *testing.T) {
+		err := m.PostToConnection(context.Background(), "gone", []byte("hello"))
+		assert.ErrorIs(t, err, ErrConnectionGone)
+	})
+
+	t.Run("unexpected status", func(t *testing.T) {
+		err := m.PostToConnection(context.Background(), "forbidden", []byte("hello"))
+		assert.ErrorIs(t, err, ErrUnexpectedStatus)
+	})
+}
+
+func TestMemoryConnectionManager(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemoryConnectionManager()
+
+	m.Connect("conn-1", ConnectionIdentity{SourceIP: "1.2.3.4"})
+
+	assert.NoError(t, m.PostToConnection(ctx, "conn-1", []byte("one")))
+	assert.NoError(t, m.PostToConnection(ctx, "conn-1", []byte("two")))
+	assert.Equal(t, [][]byte{[]byte("one"), []byte("two")}, m.Messages("conn-1"))
+
+	conn, err := m.GetConnection(ctx, "conn-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.3.4", conn.Identity.SourceIP)
+
+	assert.NoError(t, m.DeleteConnection(ctx, "conn-1"))
+	assert.True(t, m.Disconnected("conn-1"))
+	assert.ErrorIs(t, m.PostToConnection(ctx, "conn-1", []byte("three")), ErrConnectionGone)
+
+	_, err = m.GetConnection(ctx, "conn-1")
+	assert.ErrorIs(t, err, ErrConnectionGone)
+}
+
+func TestFromContext(t *testing.T) {
+	m := NewMemoryConnectionManager()
+
+	got, ok := FromContext(NewContext(context.Background(), m))
+
+	assert.True(t, ok)
+	assert.Equal(t, m, got)
+
+	_, ok = FromContext(context.Background())
+	assert.False(t, ok)
+}
+
+func TestRegionFromEndpoint(t *testing.T) {
+	assert.Equal(t, "eu-west-1", regionFromEndpoint("https://abc123.execute-api.eu-west-1.amazonaws.com/prod"))
+}